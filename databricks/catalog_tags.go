@@ -0,0 +1,58 @@
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/databricks/databricks-sdk-go/client"
+)
+
+// catalogTagAssignment is a tag assigned to a Unity Catalog entity, the
+// equivalent of a row of the *_tags views of the Information Schema.
+type catalogTagAssignment struct {
+	EntityType string `json:"entity_type"`
+	EntityName string `json:"entity_name"`
+	TagKey     string `json:"tag_key"`
+	TagValue   string `json:"tag_value,omitempty"`
+}
+
+type listCatalogTagAssignmentsResponse struct {
+	TagAssignments []catalogTagAssignment `json:"tag_assignments"`
+	NextPageToken  string                 `json:"next_page_token,omitempty"`
+}
+
+// listCatalogTagAssignments returns the tags assigned to an entity. The
+// entity type is one of catalogs, schemas, tables, columns or volumes, and
+// the entity name its full name, e.g. __catalog_name__.__schema_name__.__table_name__.__column_name__
+// for a column.
+func listCatalogTagAssignments(ctx context.Context, client *client.DatabricksClient, entityType, entityName string) ([]catalogTagAssignment, error) {
+	path := fmt.Sprintf("/api/2.1/unity-catalog/entity-tag-assignments/%s/%s/tags", entityType, url.PathEscape(entityName))
+	request := path
+
+	var assignments []catalogTagAssignment
+	for {
+		var response listCatalogTagAssignmentsResponse
+		err := client.Do(ctx, http.MethodGet, request, nil, &response)
+		if err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, response.TagAssignments...)
+
+		if response.NextPageToken == "" {
+			return assignments, nil
+		}
+		request = path + "?" + url.Values{"page_token": {response.NextPageToken}}.Encode()
+	}
+}
+
+// catalogTagsMap returns tag assignments as a map of tag keys to values.
+// Tags without a value map to an empty string.
+func catalogTagsMap(assignments []catalogTagAssignment) map[string]string {
+	tags := make(map[string]string, len(assignments))
+	for _, assignment := range assignments {
+		tags[assignment.TagKey] = assignment.TagValue
+	}
+	return tags
+}
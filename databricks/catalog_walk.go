@@ -0,0 +1,183 @@
+package databricks
+
+import (
+	"context"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// Default for the catalog_walk_concurrency connection argument
+const defaultCatalogWalkConcurrency = 5
+
+// catalogWalkItem is a schema, or catalog, streamed by the parent hydrate of
+// a catalog walk, along with the slots bounding the number of them whose
+// children the query lists at the same time.
+type catalogWalkItem[T any] struct {
+	Value T
+	slots chan struct{}
+}
+
+// catalogWalkParent is a catalogWalkItem of any type.
+type catalogWalkParent interface {
+	wait(ctx context.Context) (func(), error)
+}
+
+// Error codes returned when the catalog or schema given by the quals does not
+// exist
+var catalogNotFoundErrors = []string{"CATALOG_DOES_NOT_EXIST", "SCHEMA_DOES_NOT_EXIST"}
+
+// isCatalogWalk reports whether the children of a schema are listed by
+// walking the schemas of the metastore, as the catalog_name and schema_name
+// quals do not name a single schema.
+func isCatalogWalk(d *plugin.QueryData) bool {
+	return d.EqualsQualString("catalog_name") == "" || d.EqualsQualString("schema_name") == ""
+}
+
+// shouldIgnoreCatalogWalkError reports whether an error listing the children
+// of a schema is skipped. The plugin SDK only applies the ignore config of a
+// list to its parent hydrate, so the child lists skip the catalog or schema
+// given by the quals when it does not exist, and when walking the metastore,
+// the catalogs and schemas dropped or not readable while they are walked.
+func shouldIgnoreCatalogWalkError(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, err error) bool {
+	if isCatalogWalk(d) {
+		return shouldIgnoreErrors(catalogWalkIgnoreErrors)(ctx, d, h, err)
+	}
+	return shouldIgnoreErrors(catalogNotFoundErrors)(ctx, d, h, err)
+}
+
+// newCatalogWalkSlots returns the slots shared by the items a parent hydrate
+// streams for a query. The plugin SDK calls the child list in a goroutine for
+// each item of a parent hydrate, and the MaxConcurrency of its rate limiters
+// only applies to column hydrates, so without them every schema of the
+// metastore would be listed at once.
+func newCatalogWalkSlots(d *plugin.QueryData) chan struct{} {
+	size := defaultCatalogWalkConcurrency
+	if config := GetConfig(d.Connection); config.CatalogWalkConcurrency != nil && *config.CatalogWalkConcurrency > 0 {
+		size = *config.CatalogWalkConcurrency
+	}
+	return make(chan struct{}, size)
+}
+
+// wait blocks until the children of the item can be listed, and returns the
+// func to call once they have been.
+func (i catalogWalkItem[T]) wait(ctx context.Context) (func(), error) {
+	select {
+	case i.slots <- struct{}{}:
+		return func() { <-i.slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// listCatalogWalkCatalogs is the parent hydrate streaming the catalog given
+// by the catalog_name qual, or else every catalog of the metastore, as
+// catalogWalkItem[catalog.CatalogInfo].
+func listCatalogWalkCatalogs(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+
+	slots := newCatalogWalkSlots(d)
+	if name := d.EqualsQualString("catalog_name"); name != "" {
+		d.StreamListItem(ctx, catalogWalkItem[catalog.CatalogInfo]{Value: catalog.CatalogInfo{Name: name}, slots: slots})
+		return nil, nil
+	}
+
+	// Create client
+	client, err := getWorkspaceClient(ctx, d)
+	if err != nil {
+		logger.Error("listCatalogWalkCatalogs", "connection_error", err)
+		return nil, err
+	}
+
+	catalogs, err := client.Catalogs.ListAll(ctx)
+	if err != nil {
+		logger.Error("listCatalogWalkCatalogs", "api_error", err)
+		return nil, err
+	}
+
+	for _, item := range catalogs {
+		d.StreamListItem(ctx, catalogWalkItem[catalog.CatalogInfo]{Value: item, slots: slots})
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+	return nil, nil
+}
+
+// listCatalogWalkSchemas is the parent hydrate of the tables listing the
+// children of a schema. It streams the schema named by the catalog_name and
+// schema_name quals, or else walks the schemas of the given catalog, or of
+// every catalog, as catalogWalkItem[catalog.SchemaInfo]. Catalogs dropped or
+// not readable while walking are skipped.
+func listCatalogWalkSchemas(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	catalogName := d.EqualsQualString("catalog_name")
+	schemaName := d.EqualsQualString("schema_name")
+
+	slots := newCatalogWalkSlots(d)
+	if catalogName != "" && schemaName != "" {
+		d.StreamListItem(ctx, catalogWalkItem[catalog.SchemaInfo]{Value: catalog.SchemaInfo{CatalogName: catalogName, Name: schemaName, FullName: catalogName + "." + schemaName}, slots: slots})
+		return nil, nil
+	}
+
+	// Create client
+	client, err := getWorkspaceClient(ctx, d)
+	if err != nil {
+		logger.Error("listCatalogWalkSchemas", "connection_error", err)
+		return nil, err
+	}
+
+	catalogNames := []string{catalogName}
+	if catalogName == "" {
+		catalogs, err := client.Catalogs.ListAll(ctx)
+		if err != nil {
+			logger.Error("listCatalogWalkSchemas", "api_error", err)
+			return nil, err
+		}
+		catalogNames = nil
+		for _, item := range catalogs {
+			catalogNames = append(catalogNames, item.Name)
+		}
+	}
+
+	if err := streamCatalogWalkSchemas(ctx, d, h, client, catalogNames, schemaName, slots); err != nil {
+		logger.Error("listCatalogWalkSchemas", "api_error", err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// streamCatalogWalkSchemas streams the schemas of the given catalogs, or only
+// the schema with the given name, as catalogWalkItem[catalog.SchemaInfo].
+// Catalogs dropped or not readable while walking are skipped.
+func streamCatalogWalkSchemas(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, client *databricks.WorkspaceClient, catalogNames []string, schemaName string, slots chan struct{}) error {
+	for _, name := range catalogNames {
+		var schemas []catalog.SchemaInfo
+		if schemaName != "" {
+			// Catalogs without the schema are skipped by the child list
+			schemas = []catalog.SchemaInfo{{CatalogName: name, Name: schemaName, FullName: name + "." + schemaName}}
+		} else {
+			var err error
+			schemas, err = client.Schemas.ListAll(ctx, catalog.ListSchemasRequest{CatalogName: name})
+			if err != nil {
+				if shouldIgnoreErrors(catalogWalkIgnoreErrors)(ctx, d, h, err) {
+					continue
+				}
+				return err
+			}
+		}
+
+		for _, item := range schemas {
+			d.StreamListItem(ctx, catalogWalkItem[catalog.SchemaInfo]{Value: item, slots: slots})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,288 @@
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/compute"
+)
+
+// clusterPolicyRule is a rule of the cluster policy definition language.
+type clusterPolicyRule struct {
+	Type         string        `json:"type"`
+	Value        interface{}   `json:"value"`
+	Values       []interface{} `json:"values"`
+	Pattern      string        `json:"pattern"`
+	MinValue     *float64      `json:"minValue"`
+	MaxValue     *float64      `json:"maxValue"`
+	DefaultValue interface{}   `json:"defaultValue"`
+	IsOptional   bool          `json:"isOptional"`
+	Hidden       bool          `json:"hidden"`
+}
+
+// clusterPolicyVirtualAttributes are the policy paths which are not
+// attributes of the cluster, but are derived by Databricks from it, such as
+// the estimated DBU rate. Their rules cannot be checked against the cluster.
+var clusterPolicyVirtualAttributes = map[string]bool{
+	"cluster_type":  true,
+	"dbus_per_hour": true,
+}
+
+// clusterPolicyViolation is a cluster attribute which does not satisfy the
+// rule of a cluster policy.
+type clusterPolicyViolation struct {
+	Path     string      `json:"path"`
+	RuleType string      `json:"rule_type"`
+	Value    interface{} `json:"value"`
+	Message  string      `json:"message"`
+}
+
+// getClusterPolicyRules returns the rules of a cluster policy. For a policy
+// based on a policy family, these are the rules of the family merged with the
+// overrides of the policy. Family definitions are looked up in, and added to,
+// familyDefinitions so that they are only fetched once.
+func getClusterPolicyRules(ctx context.Context, client *databricks.WorkspaceClient, policy compute.Policy, familyDefinitions map[string]string) ([]computeClusterPolicyRuleInfo, error) {
+	var rules []computeClusterPolicyRuleInfo
+	var err error
+
+	if policy.PolicyFamilyId == "" {
+		rules, err = parseClusterPolicyRules(policy.Definition, "policy")
+	} else {
+		definition, ok := familyDefinitions[policy.PolicyFamilyId]
+		if !ok {
+			family, err := client.PolicyFamilies.GetByPolicyFamilyId(ctx, policy.PolicyFamilyId)
+			if err != nil {
+				return nil, err
+			}
+			definition = family.Definition
+			familyDefinitions[policy.PolicyFamilyId] = definition
+		}
+		rules, err = mergeClusterPolicyRules(definition, policy.PolicyFamilyDefinitionOverrides)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid definition of cluster policy %s: %v", policy.PolicyId, err)
+	}
+	return rules, nil
+}
+
+// mergeClusterPolicyRules returns the rules of a policy family definition,
+// with the rules of the policy overrides replacing those of the family for
+// the same path.
+func mergeClusterPolicyRules(familyDefinition, overrides string) ([]computeClusterPolicyRuleInfo, error) {
+	familyRules, err := parseClusterPolicyRules(familyDefinition, "policy_family")
+	if err != nil {
+		return nil, err
+	}
+	overrideRules, err := parseClusterPolicyRules(overrides, "override")
+	if err != nil {
+		return nil, err
+	}
+
+	rules := map[string]computeClusterPolicyRuleInfo{}
+	for _, rule := range append(familyRules, overrideRules...) {
+		rules[rule.Path] = rule
+	}
+
+	merged := make([]computeClusterPolicyRuleInfo, 0, len(rules))
+	for _, rule := range rules {
+		merged = append(merged, rule)
+	}
+	sort.Slice(merged, func(i, j int) bool { return merged[i].Path < merged[j].Path })
+	return merged, nil
+}
+
+// parseClusterPolicyRules parses a policy definition, a JSON object mapping
+// each attribute path to its rule, into rules sorted by path.
+func parseClusterPolicyRules(definition, source string) ([]computeClusterPolicyRuleInfo, error) {
+	if strings.TrimSpace(definition) == "" {
+		return nil, nil
+	}
+
+	var paths map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(definition), &paths); err != nil {
+		return nil, err
+	}
+
+	rules := make([]computeClusterPolicyRuleInfo, 0, len(paths))
+	for path, raw := range paths {
+		var rule clusterPolicyRule
+		if err := json.Unmarshal(raw, &rule); err != nil {
+			return nil, fmt.Errorf("rule for %s: %v", path, err)
+		}
+		rules = append(rules, computeClusterPolicyRuleInfo{
+			Path:       path,
+			Source:     source,
+			Rule:       rule,
+			Definition: raw,
+		})
+	}
+	sort.Slice(rules, func(i, j int) bool { return rules[i].Path < rules[j].Path })
+	return rules, nil
+}
+
+// evaluateClusterPolicy checks the attributes of a cluster against the rules
+// of a policy and returns the violations, sorted by path. Attributes the
+// cluster does not set are only reported for a fixed rule, as the API omits
+// attributes left at their zero value. Rules on virtual attributes, such as
+// cluster_type and dbus_per_hour, cannot be checked and are skipped, as are
+// auto: Spark version aliases, which the cluster reports resolved.
+func evaluateClusterPolicy(cluster interface{}, rules []computeClusterPolicyRuleInfo) ([]clusterPolicyViolation, error) {
+	data, err := json.Marshal(cluster)
+	if err != nil {
+		return nil, err
+	}
+	var attributes map[string]interface{}
+	if err := json.Unmarshal(data, &attributes); err != nil {
+		return nil, err
+	}
+
+	violations := []clusterPolicyViolation{}
+	for _, rule := range rules {
+		if clusterPolicyVirtualAttributes[rule.Path] {
+			continue
+		}
+
+		values := lookupClusterPolicyPath(attributes, strings.Split(rule.Path, "."))
+		if len(values) == 0 {
+			if rule.Rule.Type == "fixed" && !rule.Rule.IsOptional && !isZeroPolicyValue(rule.Rule.Value) {
+				violations = append(violations, clusterPolicyViolation{
+					Path:     rule.Path,
+					RuleType: rule.Rule.Type,
+					Message:  fmt.Sprintf("must be %v, but is not set", rule.Rule.Value),
+				})
+			}
+			continue
+		}
+		for _, value := range values {
+			if message := checkClusterPolicyRule(rule.Rule, value); message != "" {
+				violations = append(violations, clusterPolicyViolation{
+					Path:     rule.Path,
+					RuleType: rule.Rule.Type,
+					Value:    value,
+					Message:  message,
+				})
+			}
+		}
+	}
+	return violations, nil
+}
+
+// checkClusterPolicyRule returns why a value does not satisfy a rule, or an
+// empty string if it does.
+func checkClusterPolicyRule(rule clusterPolicyRule, value interface{}) string {
+	switch rule.Type {
+	case "fixed":
+		if !policyValuesEqual(value, rule.Value) && !isPolicyVersionAlias(rule.Value) {
+			return fmt.Sprintf("must be %v", rule.Value)
+		}
+	case "forbidden":
+		return "must not be set"
+	case "allowlist":
+		if !containsPolicyValue(rule.Values, value) && !slices.ContainsFunc(rule.Values, isPolicyVersionAlias) {
+			return fmt.Sprintf("must be one of %v", rule.Values)
+		}
+	case "blocklist":
+		if containsPolicyValue(rule.Values, value) {
+			return fmt.Sprintf("must not be one of %v", rule.Values)
+		}
+	case "regex":
+		// Values must match the whole pattern
+		pattern, err := regexp.Compile("^(?:" + rule.Pattern + ")$")
+		if err != nil {
+			return fmt.Sprintf("cannot be checked against invalid pattern %s", rule.Pattern)
+		}
+		if !pattern.MatchString(fmt.Sprint(value)) {
+			return fmt.Sprintf("must match %s", rule.Pattern)
+		}
+	case "range":
+		number, err := strconv.ParseFloat(fmt.Sprint(value), 64)
+		if err != nil {
+			return "must be a number"
+		}
+		if rule.MinValue != nil && number < *rule.MinValue {
+			return fmt.Sprintf("must be at least %v", *rule.MinValue)
+		}
+		if rule.MaxValue != nil && number > *rule.MaxValue {
+			return fmt.Sprintf("must be at most %v", *rule.MaxValue)
+		}
+	}
+	return ""
+}
+
+// lookupClusterPolicyPath returns the values of the cluster attributes
+// matching a policy path. A * segment matches every element of an array. The
+// keys of map attributes such as spark_conf contain dots themselves, so the
+// longest key matching the remaining segments is used.
+func lookupClusterPolicyPath(value interface{}, segments []string) []interface{} {
+	if len(segments) == 0 {
+		if value == nil {
+			return nil
+		}
+		return []interface{}{value}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for i := len(segments); i > 0; i-- {
+			if child, ok := v[strings.Join(segments[:i], ".")]; ok {
+				return lookupClusterPolicyPath(child, segments[i:])
+			}
+		}
+	case []interface{}:
+		if segments[0] == "*" {
+			var values []interface{}
+			for _, element := range v {
+				values = append(values, lookupClusterPolicyPath(element, segments[1:])...)
+			}
+			return values
+		}
+		if index, err := strconv.Atoi(segments[0]); err == nil && index >= 0 && index < len(v) {
+			return lookupClusterPolicyPath(v[index], segments[1:])
+		}
+	}
+	return nil
+}
+
+// policyValuesEqual compares values by their string form, as policies often
+// give numbers and booleans as strings, e.g. for Spark configuration.
+func policyValuesEqual(a, b interface{}) bool {
+	return fmt.Sprint(a) == fmt.Sprint(b)
+}
+
+func containsPolicyValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if policyValuesEqual(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// isPolicyVersionAlias reports whether a value is a Spark version alias such
+// as auto:latest-lts, which Databricks resolves to a version when the cluster
+// is created. The resolved version cannot be checked against the alias.
+func isPolicyVersionAlias(value interface{}) bool {
+	alias, ok := value.(string)
+	return ok && strings.HasPrefix(alias, "auto:")
+}
+
+func isZeroPolicyValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case float64:
+		return v == 0
+	case bool:
+		return !v
+	}
+	return false
+}
@@ -0,0 +1,92 @@
+package databricks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/service/compute"
+)
+
+func TestEvaluateClusterPolicy(t *testing.T) {
+	cluster := compute.ClusterDetails{
+		AutoterminationMinutes: 60,
+		NodeTypeId:             "i3.xlarge",
+		NumWorkers:             12,
+		SparkVersion:           "12.2.x-scala2.12",
+		SparkConf:              map[string]string{"spark.databricks.cluster.profile": "singleNode"},
+		CustomTags:             map[string]string{"team": "data"},
+		InitScripts: []compute.InitScriptInfo{
+			{Workspace: &compute.WorkspaceStorageInfo{Destination: "/Shared/init.sh"}},
+			{Workspace: &compute.WorkspaceStorageInfo{Destination: "/Users/bob@example.com/init.sh"}},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		definition string
+		want       []string
+	}{
+		{"fixed", `{"autotermination_minutes": {"type": "fixed", "value": 30}}`, []string{"autotermination_minutes: must be 30"}},
+		{"fixed as string", `{"autotermination_minutes": {"type": "fixed", "value": "60"}}`, nil},
+		{"fixed not set", `{"instance_pool_id": {"type": "fixed", "value": "pool-1"}}`, []string{"instance_pool_id: must be pool-1, but is not set"}},
+		{"fixed optional not set", `{"instance_pool_id": {"type": "fixed", "value": "pool-1", "isOptional": true}}`, nil},
+		{"fixed zero not set", `{"num_workers": {"type": "fixed", "value": 0}, "enable_elastic_disk": {"type": "fixed", "value": false}}`, []string{"num_workers: must be 0"}},
+		{"forbidden", `{"custom_tags.team": {"type": "forbidden"}, "instance_pool_id": {"type": "forbidden"}}`, []string{"custom_tags.team: must not be set"}},
+		{"allowlist", `{"node_type_id": {"type": "allowlist", "values": ["i3.2xlarge", "i3.4xlarge"]}}`, []string{"node_type_id: must be one of [i3.2xlarge i3.4xlarge]"}},
+		{"blocklist", `{"node_type_id": {"type": "blocklist", "values": ["i3.xlarge"]}}`, []string{"node_type_id: must not be one of [i3.xlarge]"}},
+		{"regex", `{"spark_version": {"type": "regex", "pattern": "1[3-9]\\.[0-9]+\\.x-scala.*"}}`, []string{"spark_version: must match 1[3-9]\\.[0-9]+\\.x-scala.*"}},
+		{"regex is anchored", `{"spark_version": {"type": "regex", "pattern": "2\\.x"}}`, []string{"spark_version: must match 2\\.x"}},
+		{"range", `{"num_workers": {"type": "range", "minValue": 1, "maxValue": 10}}`, []string{"num_workers: must be at most 10"}},
+		{"range not set", `{"autoscale.max_workers": {"type": "range", "maxValue": 10}}`, nil},
+		{"unlimited", `{"node_type_id": {"type": "unlimited", "defaultValue": "i3.2xlarge"}}`, nil},
+		{"map key with dots", `{"spark_conf.spark.databricks.cluster.profile": {"type": "fixed", "value": "serverless"}}`, []string{"spark_conf.spark.databricks.cluster.profile: must be serverless"}},
+		{"wildcard", `{"init_scripts.*.workspace.destination": {"type": "regex", "pattern": "/Shared/.*"}}`, []string{"init_scripts.*.workspace.destination: must match /Shared/.*"}},
+		{"index", `{"init_scripts.1.workspace.destination": {"type": "fixed", "value": "/Users/bob@example.com/init.sh"}}`, nil},
+		{"virtual attribute", `{"dbus_per_hour": {"type": "range", "maxValue": 10}}`, nil},
+		{"fixed cluster type", `{"cluster_type": {"type": "fixed", "value": "all-purpose"}}`, nil},
+		{"fixed missing attribute", `{"driver_instance_pool_id": {"type": "fixed", "value": "pool-2", "hidden": true}}`, []string{"driver_instance_pool_id: must be pool-2, but is not set"}},
+		{"fixed version alias", `{"spark_version": {"type": "fixed", "value": "auto:latest-lts"}}`, nil},
+		{"allowlist version alias", `{"spark_version": {"type": "allowlist", "values": ["auto:latest-lts", "13.3.x-scala2.12"]}}`, nil},
+		{"allowlist without version alias", `{"spark_version": {"type": "allowlist", "values": ["13.3.x-scala2.12"]}}`, []string{"spark_version: must be one of [13.3.x-scala2.12]"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := parseClusterPolicyRules(tt.definition, "policy")
+			if err != nil {
+				t.Fatalf("parseClusterPolicyRules() error = %v", err)
+			}
+			violations, err := evaluateClusterPolicy(cluster, rules)
+			if err != nil {
+				t.Fatalf("evaluateClusterPolicy() error = %v", err)
+			}
+
+			var got []string
+			for _, violation := range violations {
+				got = append(got, violation.Path+": "+violation.Message)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("violations = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeClusterPolicyRules(t *testing.T) {
+	rules, err := mergeClusterPolicyRules(
+		`{"autotermination_minutes": {"type": "unlimited", "defaultValue": 4320}, "num_workers": {"type": "fixed", "value": 0}}`,
+		`{"autotermination_minutes": {"type": "range", "maxValue": 60}}`,
+	)
+	if err != nil {
+		t.Fatalf("mergeClusterPolicyRules() error = %v", err)
+	}
+
+	var got []string
+	for _, rule := range rules {
+		got = append(got, rule.Path+" "+rule.Rule.Type+" "+rule.Source)
+	}
+	want := []string{"autotermination_minutes range override", "num_workers fixed policy_family"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("rules = %q, want %q", got, want)
+	}
+}
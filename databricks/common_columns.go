@@ -2,6 +2,8 @@ package databricks
 
 import (
 	"context"
+	"fmt"
+	"net/http"
 
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/memoize"
@@ -16,7 +18,7 @@ func commonColumnsForAccountResource() []*plugin.Column {
 			Name:        "account_id",
 			Type:        proto.ColumnType_STRING,
 			Hydrate:     getCommonColumns,
-			Transform:   transform.FromCamel(),
+			Transform:   transform.FromCamel().Transform(transform.NullIfZeroValue),
 			Description: "The Databricks Account ID in which the resource is located.",
 		},
 	}
@@ -26,6 +28,37 @@ func databricksAccountColumns(columns []*plugin.Column) []*plugin.Column {
 	return append(columns, commonColumnsForAccountResource()...)
 }
 
+// Columns defined on every workspace-level resource
+func commonColumnsForWorkspaceResource() []*plugin.Column {
+	return []*plugin.Column{
+		{
+			Name:        "workspace_id",
+			Type:        proto.ColumnType_INT,
+			Hydrate:     getWorkspaceCommonColumns,
+			Transform:   transform.FromCamel().Transform(transform.NullIfZeroValue),
+			Description: "The ID of the Databricks workspace in which the resource is located.",
+		},
+		{
+			Name:        "workspace_host",
+			Type:        proto.ColumnType_STRING,
+			Hydrate:     getWorkspaceCommonColumns,
+			Transform:   transform.FromCamel().Transform(transform.NullIfZeroValue),
+			Description: "The URL of the Databricks workspace in which the resource is located.",
+		},
+		{
+			Name:        "deployment_name",
+			Type:        proto.ColumnType_STRING,
+			Hydrate:     getWorkspaceCommonColumns,
+			Transform:   transform.FromCamel().Transform(transform.NullIfZeroValue),
+			Description: "The deployment name of the Databricks workspace in which the resource is located.",
+		},
+	}
+}
+
+func databricksWorkspaceColumns(columns []*plugin.Column) []*plugin.Column {
+	return append(databricksAccountColumns(columns), commonColumnsForWorkspaceResource()...)
+}
+
 var getCommonColumnsMemoized = plugin.HydrateFunc(getCommonColumnsUncached).Memoize(memoize.WithCacheKeyFunction(getCommonColumnsCacheKey))
 
 // Build a cache key for the call to getCommonColumnsCacheKey.
@@ -47,7 +80,53 @@ func getCommonColumnsUncached(ctx context.Context, d *plugin.QueryData, h *plugi
 			AccountId: *config.AccountId,
 		}, nil
 	}
-	return nil, nil
+
+	// Discover the account ID from the environment or profile, trying the
+	// workspace client settings first as workspace-only connections are the
+	// common case
+	for _, scope := range []clientScope{clientScopeWorkspace, clientScopeAccount} {
+		creds, err := resolveCredentials(config, scope, "")
+		if err != nil {
+			plugin.Logger(ctx).Debug("getCommonColumns", "client_type", scope, "resolve_error", err)
+			continue
+		}
+		if creds.Config.AccountID != "" {
+			return databricksCommonColumnData{
+				AccountId: creds.Config.AccountID,
+			}, nil
+		}
+	}
+
+	// Otherwise ask the workspace for the account it belongs to. The account
+	// ID is left empty when the workspace does not report one.
+	accountId, err := getWorkspaceAccountId(ctx, d)
+	if err != nil {
+		plugin.Logger(ctx).Debug("getCommonColumns", "host_metadata_error", err)
+		return databricksCommonColumnData{}, nil
+	}
+
+	return databricksCommonColumnData{
+		AccountId: accountId,
+	}, nil
+}
+
+// getWorkspaceAccountId returns the ID of the account a workspace belongs to,
+// as reported in the host metadata of the workspace.
+func getWorkspaceAccountId(ctx context.Context, d *plugin.QueryData) (string, error) {
+	client, err := getWorkspaceAPIClient(ctx, d)
+	if err != nil {
+		return "", err
+	}
+
+	var metadata struct {
+		AccountId   string `json:"account_id,omitempty"`
+		WorkspaceId string `json:"workspace_id,omitempty"`
+	}
+	err = client.Do(ctx, http.MethodGet, "/.well-known/databricks-config", nil, &metadata)
+	if err != nil {
+		return "", err
+	}
+	return metadata.AccountId, nil
 }
 
 // declare a wrapper hydrate function to call the memoized function
@@ -64,3 +143,60 @@ func getAccountIdForConnection(ctx context.Context, d *plugin.QueryData, h *plug
 type databricksCommonColumnData struct {
 	AccountId string
 }
+
+var getWorkspaceCommonColumnsMemoized = plugin.HydrateFunc(getWorkspaceCommonColumnsUncached).Memoize(memoize.WithCacheKeyFunction(getWorkspaceCommonColumnsCacheKey))
+
+// Build a cache key for the call to getWorkspaceCommonColumns, the workspace
+// host of the matrix item is included so each aggregated workspace is resolved
+// separately.
+func getWorkspaceCommonColumnsCacheKey(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	key := fmt.Sprintf("getWorkspaceCommonColumns-%s", d.EqualsQualString(matrixKeyWorkspaceHost))
+	return key, nil
+}
+
+// declare a wrapper hydrate function to call the memoized function
+// - this is required when a memoized function is used for a column definition
+func getWorkspaceCommonColumns(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	return getWorkspaceCommonColumnsMemoized(ctx, d, h)
+}
+
+func getWorkspaceCommonColumnsUncached(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+
+	// Create client
+	client, err := getWorkspaceClient(ctx, d)
+	if err != nil {
+		logger.Error("getWorkspaceCommonColumns", "connection_error", err)
+		return nil, err
+	}
+
+	host := client.Config.CanonicalHostName()
+	data := databricksWorkspaceCommonColumnData{
+		WorkspaceHost:  host,
+		DeploymentName: deploymentNameFromHost(host),
+	}
+
+	// The workspace ID is already known when workspaces are aggregated
+	if id := d.EqualsQuals[matrixKeyWorkspaceId]; id != nil {
+		data.WorkspaceId = id.GetInt64Value()
+		return data, nil
+	}
+
+	// The current metastore assignment includes the workspace ID, but is only
+	// available for Unity Catalog enabled workspaces
+	assignment, err := client.Metastores.Current(ctx)
+	if err != nil {
+		logger.Debug("getWorkspaceCommonColumns", "metastore_assignment_error", err)
+		data.WorkspaceId = workspaceIdFromHost(host)
+		return data, nil
+	}
+	data.WorkspaceId = assignment.WorkspaceId
+
+	return data, nil
+}
+
+type databricksWorkspaceCommonColumnData struct {
+	WorkspaceId    int64
+	WorkspaceHost  string
+	DeploymentName string
+}
@@ -0,0 +1,41 @@
+package databricks
+
+import (
+	"testing"
+)
+
+func TestCommonColumnsAccountIdFromWorkspace(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newWorkspaceTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_job", []string{"job_id", "account_id", "workspace_id"}, nil, 0)
+
+	if len(rows) == 0 {
+		t.Fatalf("got no jobs")
+	}
+	for _, row := range rows {
+		if row["account_id"] != fakeAccountId || row["workspace_id"] != int64(fakeWorkspaceId) {
+			t.Errorf("job %v: account_id = %v and workspace_id = %v, want %s and %d", row["job_id"], row["account_id"], row["workspace_id"], fakeAccountId, fakeWorkspaceId)
+		}
+	}
+	if got := len(fake.requestsTo("/.well-known/databricks-config")); got != 1 {
+		t.Errorf("got %d requests for the host metadata, want 1", got)
+	}
+}
+
+func TestCommonColumnsAccountIdUnavailable(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	fake.unavailable["/.well-known/databricks-config"] = 1
+	p := newWorkspaceTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_job", []string{"job_id", "account_id"}, nil, 0)
+
+	if len(rows) == 0 {
+		t.Fatalf("got no jobs")
+	}
+	for _, row := range rows {
+		if row["account_id"] != nil {
+			t.Errorf("job %v: account_id = %v, want null", row["job_id"], row["account_id"])
+		}
+	}
+}
@@ -14,8 +14,35 @@ type databricksConfig struct {
 	ConfigFilePath *string `hcl:"config_file_path"`
 	Username       *string `hcl:"username"`
 	Password       *string `hcl:"password"`
-        ClientID       *string `hcl:"client_id"`
-        ClientSecret   *string `hcl:"client_secret"`
+	ClientID       *string `hcl:"client_id"`
+	ClientSecret   *string `hcl:"client_secret"`
+	AuthType       *string `hcl:"auth_type"`
+
+	AzureClientID            *string `hcl:"azure_client_id"`
+	AzureClientSecret        *string `hcl:"azure_client_secret"`
+	AzureTenantID            *string `hcl:"azure_tenant_id"`
+	AzureWorkspaceResourceID *string `hcl:"azure_workspace_resource_id"`
+	AzureUseMSI              *bool   `hcl:"azure_use_msi"`
+
+	GoogleServiceAccount *string `hcl:"google_service_account"`
+	GoogleCredentials    *string `hcl:"google_credentials"`
+
+	AggregateWorkspaces *bool    `hcl:"aggregate_workspaces"`
+	IncludeWorkspaces   []string `hcl:"include_workspaces,optional"`
+	ExcludeWorkspaces   []string `hcl:"exclude_workspaces,optional"`
+
+	MaxRetries            *int `hcl:"max_retries"`
+	MaxErrorRetryAttempts *int `hcl:"max_error_retry_attempts"`
+	MinRetryDelay         *int `hcl:"min_retry_delay"`
+
+	RateLimit *int `hcl:"rate_limit"`
+
+	IgnoreErrorCodes []string `hcl:"ignore_error_codes,optional"`
+
+	MaxJobRunOutputSize *int `hcl:"max_job_run_output_size"`
+
+	CatalogWalkConcurrency *int  `hcl:"catalog_walk_concurrency"`
+	UseTableSummaries      *bool `hcl:"use_table_summaries"`
 }
 
 func ConfigInstance() interface{} {
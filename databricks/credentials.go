@@ -0,0 +1,324 @@
+package databricks
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/config"
+)
+
+type clientScope string
+
+const (
+	clientScopeAccount   clientScope = "account"
+	clientScopeWorkspace clientScope = "workspace"
+)
+
+// Sources a client setting can be resolved from, in order of precedence
+const (
+	settingSourceConnectionConfig = "connection_config"
+	settingSourceWorkspaceMatrix  = "aggregated_workspace"
+	settingSourceEnvironment      = "environment"
+	settingSourceProfile          = "profile"
+)
+
+// resolvedSetting is a single client setting along with the source it was
+// resolved from. Sensitive values are masked.
+type resolvedSetting struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Source string `json:"source"`
+}
+
+// resolvedCredentials is the result of resolving the connection config,
+// environment variables and profile into a client config.
+type resolvedCredentials struct {
+	Scope          clientScope
+	AuthType       string
+	AuthTypeSource string
+	Config         *config.Config
+	Settings       []resolvedSetting
+}
+
+// authMethodAttributes maps each authentication method to the client config
+// attributes which select it. The method names match the SDK auth types.
+var authMethodAttributes = []struct {
+	AuthType   string
+	Attributes []string
+}{
+	{"pat", []string{"token"}},
+	{"basic", []string{"username", "password"}},
+	{"oauth-m2m", []string{"client_id", "client_secret"}},
+	{"azure-client-secret", []string{"azure_client_secret"}},
+	{"azure-msi", []string{"azure_use_msi"}},
+	{"google-credentials", []string{"google_credentials"}},
+	{"google-id", []string{"google_service_account"}},
+}
+
+// resolveCredentials builds the client config for the given scope. Settings
+// in the connection config take precedence over environment variables, which
+// take precedence over the profile. The authentication method is chosen from
+// the highest precedence source that configures one, and combinations which
+// are ambiguous within a source are rejected. A profile or config file set in
+// the connection config is used as is, without any credentials from the
+// environment. An optional host overrides the configured host, e.g. for an
+// aggregated workspace.
+func resolveCredentials(databricksConfig databricksConfig, scope clientScope, host string) (*resolvedCredentials, error) {
+	hclSettings := connectionConfigSettings(databricksConfig, scope)
+	if host != "" {
+		hclSettings["host"] = host
+	}
+
+	cfg := &config.Config{}
+	for _, attr := range config.ConfigAttributes {
+		value, ok := hclSettings[attr.Name]
+		if !ok || value == "" {
+			continue
+		}
+		if err := attr.SetS(cfg, value); err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", attr.Name, err)
+		}
+	}
+
+	creds := &resolvedCredentials{
+		Scope:  scope,
+		Config: cfg,
+	}
+
+	// A profile chosen in the connection config takes precedence over any
+	// credentials in the environment
+	_, hclProfile := hclSettings["profile"]
+	_, hclConfigFile := hclSettings["config_file"]
+	useProfile := hclProfile || hclConfigFile
+
+	// An explicit auth type always wins
+	if cfg.AuthType != "" {
+		creds.AuthType = cfg.AuthType
+		creds.AuthTypeSource = settingSourceConnectionConfig
+	} else if envAuthType := os.Getenv("DATABRICKS_AUTH_TYPE"); envAuthType != "" && !useProfile {
+		creds.AuthType = envAuthType
+		creds.AuthTypeSource = settingSourceEnvironment
+	}
+
+	if creds.AuthType == "" {
+		// Credentials in the connection config take precedence over any in the
+		// environment, so pin the auth type to stop the SDK from picking up a
+		// stray environment variable instead
+		methods := authMethodsFrom(func(name string) string { return hclSettings[name] })
+		switch len(methods) {
+		case 0:
+			// Fall through to the environment
+		case 1:
+			creds.AuthType = methods[0]
+			creds.AuthTypeSource = settingSourceConnectionConfig
+			cfg.AuthType = methods[0]
+		default:
+			return nil, fmt.Errorf("ambiguous %s credentials in connection config: %s are all configured, remove all but one or set auth_type", scope, strings.Join(methods, ", "))
+		}
+	}
+
+	if creds.AuthType == "" && useProfile {
+		// Leave the auth type to the profile, and only take the settings it
+		// does not configure from the environment, so that a stray
+		// DATABRICKS_TOKEN cannot override it
+		creds.AuthTypeSource = settingSourceProfile
+		cfg.Loaders = []config.Loader{config.ConfigFile, environmentSettingsLoader{}}
+	} else if creds.AuthType == "" {
+		methods := authMethodsFrom(readEnvAttribute)
+		switch len(methods) {
+		case 0:
+			// Leave the auth type to the profile
+			creds.AuthTypeSource = settingSourceProfile
+		case 1:
+			creds.AuthType = methods[0]
+			creds.AuthTypeSource = settingSourceEnvironment
+			cfg.AuthType = methods[0]
+		default:
+			return nil, fmt.Errorf("ambiguous %s credentials in environment variables: %s are all configured, unset all but one or set DATABRICKS_AUTH_TYPE", scope, strings.Join(methods, ", "))
+		}
+	}
+
+	// Load the remaining settings from the environment and profile
+	if err := cfg.EnsureResolved(); err != nil {
+		return nil, fmt.Errorf("unable to resolve %s credentials: %w", scope, err)
+	}
+
+	// The account ID is only required by the account client, workspace-only
+	// connections do not need to know it
+	if scope == clientScopeAccount && cfg.AccountID == "" {
+		return nil, errors.New("account_id must be configured")
+	}
+
+	if creds.AuthType == "" && cfg.AuthType != "" {
+		creds.AuthType = cfg.AuthType
+	}
+
+	creds.Settings = resolvedSettings(cfg, hclSettings, host)
+	for i := range creds.Settings {
+		if creds.Settings[i].Name == "auth_type" {
+			creds.Settings[i].Source = creds.AuthTypeSource
+		}
+	}
+
+	return creds, nil
+}
+
+// connectionConfigSettings returns the client config attributes set in the
+// connection config for the given scope, keyed by SDK attribute name.
+func connectionConfigSettings(databricksConfig databricksConfig, scope clientScope) map[string]string {
+	settings := map[string]string{}
+	set := func(name string, value *string) {
+		if value != nil && *value != "" {
+			settings[name] = *value
+		}
+	}
+	setBool := func(name string, value *bool) {
+		if value != nil && *value {
+			settings[name] = strconv.FormatBool(*value)
+		}
+	}
+
+	switch scope {
+	case clientScopeAccount:
+		set("host", databricksConfig.AccountHost)
+		set("token", databricksConfig.AccountToken)
+	case clientScopeWorkspace:
+		set("host", databricksConfig.WorkspaceHost)
+		set("token", databricksConfig.WorkspaceToken)
+	}
+
+	set("account_id", databricksConfig.AccountId)
+	set("profile", databricksConfig.Profile)
+	set("config_file", databricksConfig.ConfigFilePath)
+	set("username", databricksConfig.Username)
+	set("password", databricksConfig.Password)
+	set("client_id", databricksConfig.ClientID)
+	set("client_secret", databricksConfig.ClientSecret)
+	set("auth_type", databricksConfig.AuthType)
+	set("azure_client_id", databricksConfig.AzureClientID)
+	set("azure_client_secret", databricksConfig.AzureClientSecret)
+	set("azure_tenant_id", databricksConfig.AzureTenantID)
+	set("azure_workspace_resource_id", databricksConfig.AzureWorkspaceResourceID)
+	setBool("azure_use_msi", databricksConfig.AzureUseMSI)
+	set("google_service_account", databricksConfig.GoogleServiceAccount)
+	set("google_credentials", databricksConfig.GoogleCredentials)
+
+	if databricksConfig.RateLimit != nil && *databricksConfig.RateLimit > 0 {
+		settings["rate_limit"] = strconv.Itoa(*databricksConfig.RateLimit)
+	}
+
+	// Throttled requests are retried by the SDK client, for about as many
+	// retries as max_retries allows
+	maxRetries := defaultMaxRetries
+	if databricksConfig.MaxRetries != nil {
+		maxRetries = min(max(*databricksConfig.MaxRetries, 0), maxRetryAttemptsLimit)
+	}
+	settings["retry_timeout_seconds"] = strconv.Itoa(clientRetryTimeoutSeconds(maxRetries))
+
+	return settings
+}
+
+// authMethodsFrom returns the authentication methods configured by a single
+// source.
+func authMethodsFrom(get func(name string) string) []string {
+	var methods []string
+	for _, method := range authMethodAttributes {
+		for _, name := range method.Attributes {
+			if get(name) != "" {
+				methods = append(methods, method.AuthType)
+				break
+			}
+		}
+	}
+
+	// A Google service account may be impersonated using explicit credentials
+	if len(methods) == 2 && methods[0] == "google-credentials" && methods[1] == "google-id" {
+		methods = methods[:1]
+	}
+
+	return methods
+}
+
+// environmentSettingsLoader loads the client settings which are not
+// credentials from environment variables, without overwriting any setting
+// that is already resolved.
+type environmentSettingsLoader struct{}
+
+func (environmentSettingsLoader) Name() string {
+	return "environment-settings"
+}
+
+func (environmentSettingsLoader) Configure(cfg *config.Config) error {
+	for _, attr := range config.ConfigAttributes {
+		if attr.Auth != "" || attr.Name == "auth_type" || !attr.IsZero(cfg) {
+			continue
+		}
+		if value := attr.ReadEnv(); value != "" {
+			if err := attr.SetS(cfg, value); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func readEnvAttribute(name string) string {
+	for _, attr := range config.ConfigAttributes {
+		if attr.Name == name {
+			return attr.ReadEnv()
+		}
+	}
+	return ""
+}
+
+// resolvedSettings lists every non-empty setting of the resolved client
+// config along with its source, with sensitive values masked.
+func resolvedSettings(cfg *config.Config, hclSettings map[string]string, host string) []resolvedSetting {
+	var settings []resolvedSetting
+	for _, attr := range config.ConfigAttributes {
+		if attr.Internal || attr.IsZero(cfg) {
+			continue
+		}
+
+		source := settingSourceProfile
+		if attr.Name == "host" && host != "" {
+			source = settingSourceWorkspaceMatrix
+		} else if _, ok := hclSettings[attr.Name]; ok {
+			source = settingSourceConnectionConfig
+		} else if env := attr.ReadEnv(); env != "" && env == attr.GetString(cfg) {
+			source = settingSourceEnvironment
+		}
+
+		value := attr.GetString(cfg)
+		if attr.Sensitive {
+			value = "***"
+		}
+
+		settings = append(settings, resolvedSetting{
+			Name:   attr.Name,
+			Value:  value,
+			Source: source,
+		})
+	}
+
+	sort.Slice(settings, func(i, j int) bool {
+		return settings[i].Name < settings[j].Name
+	})
+
+	return settings
+}
+
+// settingSource returns the source of the named setting, or an empty string
+// if the setting was not resolved.
+func (c *resolvedCredentials) settingSource(name string) string {
+	for _, setting := range c.Settings {
+		if setting.Name == name {
+			return setting.Source
+		}
+	}
+	return ""
+}
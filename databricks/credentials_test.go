@@ -0,0 +1,157 @@
+package databricks
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testProfiles = `[DEFAULT]
+host  = https://default.cloud.databricks.com
+token = dapi-default-token
+
+[dev]
+host          = https://dev.cloud.databricks.com
+client_id     = dev-client
+client_secret = dev-secret
+`
+
+func TestResolveCredentials(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), ".databrickscfg")
+	if err := os.WriteFile(configFile, []byte(testProfiles), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	str := func(value string) *string { return &value }
+
+	for _, test := range []struct {
+		name           string
+		config         databricksConfig
+		scope          clientScope
+		env            map[string]string
+		wantAuthType   string
+		wantSource     string
+		wantToken      string
+		wantClientID   string
+		wantErr        string
+		wantHostSource string
+	}{
+		{
+			name:         "token in connection config",
+			config:       databricksConfig{WorkspaceHost: str("https://ws.cloud.databricks.com"), WorkspaceToken: str("dapi-hcl")},
+			wantAuthType: "pat",
+			wantSource:   settingSourceConnectionConfig,
+			wantToken:    "dapi-hcl",
+		},
+		{
+			name:         "connection config over environment",
+			config:       databricksConfig{WorkspaceHost: str("https://ws.cloud.databricks.com"), WorkspaceToken: str("dapi-hcl")},
+			env:          map[string]string{"DATABRICKS_CLIENT_ID": "env-client", "DATABRICKS_CLIENT_SECRET": "env-secret"},
+			wantAuthType: "pat",
+			wantSource:   settingSourceConnectionConfig,
+			wantToken:    "dapi-hcl",
+			// Loaded, but unused as the auth type is pinned
+			wantClientID: "env-client",
+		},
+		{
+			name:           "token in environment",
+			env:            map[string]string{"DATABRICKS_HOST": "https://env.cloud.databricks.com", "DATABRICKS_TOKEN": "dapi-env"},
+			wantAuthType:   "pat",
+			wantSource:     settingSourceEnvironment,
+			wantToken:      "dapi-env",
+			wantHostSource: settingSourceEnvironment,
+		},
+		{
+			name:    "ambiguous connection config",
+			config:  databricksConfig{WorkspaceToken: str("dapi-hcl"), Username: str("user"), Password: str("secret")},
+			wantErr: "ambiguous workspace credentials in connection config: pat, basic",
+		},
+		{
+			name:         "ambiguous connection config with auth type",
+			config:       databricksConfig{WorkspaceToken: str("dapi-hcl"), Username: str("user"), Password: str("secret"), AuthType: str("basic")},
+			wantAuthType: "basic",
+			wantSource:   settingSourceConnectionConfig,
+			wantToken:    "dapi-hcl",
+		},
+		{
+			name:    "ambiguous environment",
+			env:     map[string]string{"DATABRICKS_TOKEN": "dapi-env", "DATABRICKS_CLIENT_ID": "env-client", "DATABRICKS_CLIENT_SECRET": "env-secret"},
+			wantErr: "ambiguous workspace credentials in environment variables: pat, oauth-m2m",
+		},
+		{
+			name:         "auth type in environment",
+			env:          map[string]string{"DATABRICKS_TOKEN": "dapi-env", "DATABRICKS_CLIENT_ID": "env-client", "DATABRICKS_CLIENT_SECRET": "env-secret", "DATABRICKS_AUTH_TYPE": "oauth-m2m"},
+			wantAuthType: "oauth-m2m",
+			wantSource:   settingSourceEnvironment,
+			wantToken:    "dapi-env",
+			wantClientID: "env-client",
+		},
+		{
+			name:           "profile in connection config over environment",
+			config:         databricksConfig{Profile: str("dev"), ConfigFilePath: str(configFile)},
+			env:            map[string]string{"DATABRICKS_TOKEN": "dapi-env", "DATABRICKS_AUTH_TYPE": "pat"},
+			wantSource:     settingSourceProfile,
+			wantClientID:   "dev-client",
+			wantHostSource: settingSourceProfile,
+		},
+		{
+			name:           "config file in connection config over environment",
+			config:         databricksConfig{ConfigFilePath: str(configFile)},
+			env:            map[string]string{"DATABRICKS_HOST": "https://env.cloud.databricks.com", "DATABRICKS_TOKEN": "dapi-env"},
+			wantSource:     settingSourceProfile,
+			wantToken:      "dapi-default-token",
+			wantHostSource: settingSourceProfile,
+		},
+		{
+			name:         "profile with credentials in connection config",
+			config:       databricksConfig{Profile: str("dev"), ConfigFilePath: str(configFile), WorkspaceToken: str("dapi-hcl")},
+			wantAuthType: "pat",
+			wantSource:   settingSourceConnectionConfig,
+			wantToken:    "dapi-hcl",
+			wantClientID: "dev-client",
+		},
+		{
+			name:    "account without account id",
+			config:  databricksConfig{AccountHost: str("https://accounts.cloud.databricks.com"), AccountToken: str("dapi-hcl")},
+			scope:   clientScopeAccount,
+			wantErr: "account_id must be configured",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			for name, value := range test.env {
+				t.Setenv(name, value)
+			}
+			scope := test.scope
+			if scope == "" {
+				scope = clientScopeWorkspace
+			}
+
+			creds, err := resolveCredentials(test.config, scope, "")
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("error = %v, want %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if creds.AuthType != test.wantAuthType || creds.AuthTypeSource != test.wantSource {
+				t.Errorf("auth type = %q from %s, want %q from %s", creds.AuthType, creds.AuthTypeSource, test.wantAuthType, test.wantSource)
+			}
+			if creds.Config.Token != test.wantToken {
+				t.Errorf("token = %q, want %q", creds.Config.Token, test.wantToken)
+			}
+			if creds.Config.ClientID != test.wantClientID {
+				t.Errorf("client_id = %q, want %q", creds.Config.ClientID, test.wantClientID)
+			}
+			if test.wantHostSource != "" {
+				if got := creds.settingSource("host"); got != test.wantHostSource {
+					t.Errorf("host source = %q, want %q", got, test.wantHostSource)
+				}
+			}
+		})
+	}
+}
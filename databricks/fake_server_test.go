@@ -0,0 +1,792 @@
+package databricks
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeDatabricks is an in-process Databricks REST API serving canned
+// fixtures from testdata. It covers the SCIM, Jobs, Clusters, Unity Catalog,
+// SQL and Workspace endpoints the tables call, and records every request so
+// that tests can assert on pagination and qual pushdown.
+type fakeDatabricks struct {
+	*httptest.Server
+
+	// Maximum number of items returned per page by the token paginated APIs,
+	// mirroring the server-side caps of the real APIs
+	pageSize int
+
+	// Object IDs whose permissions cannot be read by the caller
+	permissionDenied map[string]bool
+
+	// Delay before responding to each request, so that tests can observe
+	// the number of requests in flight
+	latency time.Duration
+
+	// Task run IDs whose output is not available yet
+	pendingOutputs map[string]bool
+
+	// Number of times requests to each path fail with a transient error
+	// before they succeed
+	unavailable map[string]int
+
+	// Number of times requests to each path are throttled with a 429
+	// response before they succeed
+	throttled map[string]int
+
+	users      []map[string]interface{}
+	jobs       []map[string]interface{}
+	runs       []map[string]interface{}
+	queries    []map[string]interface{}
+	outputs    []map[string]interface{}
+	clusters   []map[string]interface{}
+	events     []map[string]interface{}
+	libraries  []map[string]interface{}
+	policies   []map[string]interface{}
+	families   []map[string]interface{}
+	lineage    []map[string]interface{}
+	catalogs   []map[string]interface{}
+	schemas    []map[string]interface{}
+	tables     []map[string]interface{}
+	volumes    []map[string]interface{}
+	functions  []map[string]interface{}
+	locations  []map[string]interface{}
+	creds      []map[string]interface{}
+	conns      []map[string]interface{}
+	shares     []map[string]interface{}
+	grants     []map[string]interface{}
+	tags       []map[string]interface{}
+	warehouses []map[string]interface{}
+	repos      []map[string]interface{}
+
+	mu          sync.Mutex
+	requests    []fakeRequest
+	inFlight    map[string]int
+	maxInFlight map[string]int
+}
+
+type fakeRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Body   map[string]interface{}
+}
+
+func newFakeDatabricks(t *testing.T) *fakeDatabricks {
+	t.Helper()
+
+	f := &fakeDatabricks{
+		pageSize:         2,
+		permissionDenied: map[string]bool{},
+		pendingOutputs:   map[string]bool{},
+		unavailable:      map[string]int{},
+		throttled:        map[string]int{},
+		inFlight:         map[string]int{},
+		maxInFlight:      map[string]int{},
+		users:            loadFixture(t, "users.json"),
+		jobs:             loadFixture(t, "jobs.json"),
+		runs:             loadFixture(t, "runs.json"),
+		queries:          loadFixture(t, "queries.json"),
+		outputs:          loadFixture(t, "run_outputs.json"),
+		clusters:         loadFixture(t, "clusters.json"),
+		events:           loadFixture(t, "cluster_events.json"),
+		libraries:        loadFixture(t, "cluster_libraries.json"),
+		policies:         loadFixture(t, "cluster_policies.json"),
+		families:         loadFixture(t, "policy_families.json"),
+		lineage:          loadFixture(t, "lineage.json"),
+		catalogs:         loadFixture(t, "catalogs.json"),
+		schemas:          loadFixture(t, "schemas.json"),
+		tables:           loadFixture(t, "tables.json"),
+		volumes:          loadFixture(t, "volumes.json"),
+		functions:        loadFixture(t, "functions.json"),
+		locations:        loadFixture(t, "external_locations.json"),
+		creds:            loadFixture(t, "storage_credentials.json"),
+		conns:            loadFixture(t, "connections.json"),
+		shares:           loadFixture(t, "shares.json"),
+		grants:           loadFixture(t, "grants.json"),
+		tags:             loadFixture(t, "entity_tags.json"),
+		warehouses:       loadFixture(t, "warehouses.json"),
+		repos:            loadFixture(t, "repos.json"),
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(f.serveHTTP))
+	t.Cleanup(f.Close)
+
+	return f
+}
+
+func loadFixture(t *testing.T, name string) []map[string]interface{} {
+	t.Helper()
+
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("reading fixture %s: %v", name, err)
+	}
+	var items []map[string]interface{}
+	if err := json.Unmarshal(data, &items); err != nil {
+		t.Fatalf("parsing fixture %s: %v", name, err)
+	}
+	return items
+}
+
+// maxRequestsInFlight returns the maximum number of requests to the given
+// path served at the same time.
+func (f *fakeDatabricks) maxRequestsInFlight(path string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.maxInFlight[path]
+}
+
+// requestsTo returns the recorded requests for the given path.
+func (f *fakeDatabricks) requestsTo(path string) []fakeRequest {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var requests []fakeRequest
+	for _, r := range f.requests {
+		if r.Path == path {
+			requests = append(requests, r)
+		}
+	}
+	return requests
+}
+
+func (f *fakeDatabricks) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	var body map[string]interface{}
+	if data, _ := io.ReadAll(r.Body); len(data) > 0 {
+		_ = json.Unmarshal(data, &body)
+	}
+
+	f.mu.Lock()
+	f.requests = append(f.requests, fakeRequest{Method: r.Method, Path: r.URL.Path, Query: r.URL.Query(), Body: body})
+	f.inFlight[r.URL.Path]++
+	f.maxInFlight[r.URL.Path] = max(f.maxInFlight[r.URL.Path], f.inFlight[r.URL.Path])
+	f.mu.Unlock()
+
+	defer func() {
+		f.mu.Lock()
+		f.inFlight[r.URL.Path]--
+		f.mu.Unlock()
+	}()
+	time.Sleep(f.latency)
+
+	if r.Header.Get("Authorization") != "Bearer "+fakeToken {
+		writeError(w, http.StatusUnauthorized, "UNAUTHENTICATED", "invalid access token")
+		return
+	}
+
+	f.mu.Lock()
+	unavailable := f.unavailable[r.URL.Path] > 0
+	if unavailable {
+		f.unavailable[r.URL.Path]--
+	}
+	throttled := !unavailable && f.throttled[r.URL.Path] > 0
+	if throttled {
+		f.throttled[r.URL.Path]--
+	}
+	f.mu.Unlock()
+	if unavailable {
+		writeError(w, http.StatusServiceUnavailable, "TEMPORARILY_UNAVAILABLE", "the service is temporarily unavailable")
+		return
+	}
+	if throttled {
+		w.Header().Set("Retry-After", "60")
+		writeError(w, http.StatusTooManyRequests, "REQUEST_LIMIT_EXCEEDED", "too many requests")
+		return
+	}
+
+	path := r.URL.Path
+	query := r.URL.Query()
+
+	switch {
+	// SCIM
+	case path == "/api/2.0/preview/scim/v2/Users":
+		f.listSCIM(w, query, f.users)
+	case strings.HasPrefix(path, "/api/2.0/preview/scim/v2/Users/"):
+		f.getSCIM(w, f.users, strings.TrimPrefix(path, "/api/2.0/preview/scim/v2/Users/"))
+
+	// Jobs
+	case path == "/api/2.1/jobs/list":
+		items := filterItems(f.jobs, func(item map[string]interface{}) bool {
+			name := query.Get("name")
+			return name == "" || lookup(item, "settings", "name") == name
+		})
+		f.listPage(w, query, "jobs", items, "page_token", "next_page_token", "has_more")
+	case path == "/api/2.1/jobs/get":
+		f.getItem(w, f.jobs, "job_id", query.Get("job_id"), http.StatusBadRequest, "INVALID_PARAMETER_VALUE", "Job %s does not exist.")
+	case path == "/api/2.1/jobs/runs/list":
+		if jobId := query.Get("job_id"); jobId != "" && !slices.ContainsFunc(f.jobs, func(item map[string]interface{}) bool { return lookup(item, "job_id") == jobId }) {
+			writeError(w, http.StatusBadRequest, "INVALID_PARAMETER_VALUE", fmt.Sprintf("Job %s does not exist.", jobId))
+			return
+		}
+		f.listPage(w, query, "runs", filterRuns(f.runs, query), "page_token", "next_page_token", "has_more")
+	case path == "/api/2.1/jobs/runs/get":
+		f.getItem(w, f.runs, "run_id", query.Get("run_id"), http.StatusBadRequest, "INVALID_PARAMETER_VALUE", "Run %s does not exist.")
+	case path == "/api/2.1/jobs/runs/get-output":
+		f.getRunOutput(w, query.Get("run_id"))
+	case strings.HasPrefix(path, "/api/2.0/permissions/"):
+		f.getPermissions(w, path)
+
+	// Clusters
+	case path == "/api/2.0/clusters/list":
+		writeJSON(w, map[string]interface{}{"clusters": f.clusters})
+	case path == "/api/2.0/clusters/get":
+		f.getItem(w, f.clusters, "cluster_id", query.Get("cluster_id"), http.StatusBadRequest, "INVALID_PARAMETER_VALUE", "Cluster %s does not exist")
+	case path == "/api/2.0/clusters/events":
+		f.listClusterEvents(w, body)
+
+	// Cluster policies
+	case path == "/api/2.0/policies/clusters/list":
+		writeJSON(w, map[string]interface{}{"policies": f.policies})
+	case path == "/api/2.0/policies/clusters/get":
+		f.getItem(w, f.policies, "policy_id", query.Get("policy_id"), http.StatusBadRequest, "INVALID_PARAMETER_VALUE", "Cluster policy %s does not exist")
+	case strings.HasPrefix(path, "/api/2.0/policy-families/"):
+		f.getItem(w, f.families, "policy_family_id", strings.TrimPrefix(path, "/api/2.0/policy-families/"), http.StatusNotFound, "RESOURCE_DOES_NOT_EXIST", "Policy family %s does not exist")
+
+	// Libraries
+	case path == "/api/2.0/libraries/all-cluster-statuses":
+		writeJSON(w, map[string]interface{}{"statuses": f.libraries})
+	case path == "/api/2.0/libraries/cluster-status":
+		f.getItem(w, f.libraries, "cluster_id", query.Get("cluster_id"), http.StatusBadRequest, "INVALID_PARAMETER_VALUE", "Cluster %s does not exist")
+
+	// Unity Catalog
+	case path == "/api/2.1/unity-catalog/catalogs":
+		writeJSON(w, map[string]interface{}{"catalogs": f.catalogs})
+	case strings.HasPrefix(path, "/api/2.1/unity-catalog/catalogs/"):
+		f.getItem(w, f.catalogs, "name", strings.TrimPrefix(path, "/api/2.1/unity-catalog/catalogs/"), http.StatusNotFound, "CATALOG_DOES_NOT_EXIST", "Catalog '%s' does not exist.")
+	case path == "/api/2.1/unity-catalog/schemas":
+		if items, ok := f.childItems(w, f.catalogs, f.schemas, query.Get("catalog_name")); ok {
+			writeJSON(w, map[string]interface{}{"schemas": items})
+		}
+	case path == "/api/2.1/unity-catalog/tables":
+		if items, ok := f.childItems(w, f.schemas, f.tables, query.Get("catalog_name"), query.Get("schema_name")); ok {
+			f.listPage(w, query, "tables", items, "page_token", "next_page_token", "")
+		}
+	case path == "/api/2.1/unity-catalog/table-summaries":
+		if items, ok := f.childItems(w, f.catalogs, f.tables, query.Get("catalog_name")); ok {
+			summaries := []map[string]interface{}{}
+			for _, item := range items {
+				if likePattern(query.Get("schema_name_pattern")).MatchString(lookup(item, "schema_name")) {
+					summaries = append(summaries, map[string]interface{}{"full_name": item["full_name"], "table_type": item["table_type"]})
+				}
+			}
+			f.listPage(w, query, "tables", summaries, "page_token", "next_page_token", "")
+		}
+	case strings.HasPrefix(path, "/api/2.1/unity-catalog/tables/"):
+		f.getItem(w, f.tables, "full_name", strings.TrimPrefix(path, "/api/2.1/unity-catalog/tables/"), http.StatusNotFound, "TABLE_DOES_NOT_EXIST", "Table '%s' does not exist.")
+	case strings.HasPrefix(path, "/api/2.1/unity-catalog/entity-tag-assignments/"):
+		entityType, entityName, _ := strings.Cut(strings.TrimSuffix(strings.TrimPrefix(path, "/api/2.1/unity-catalog/entity-tag-assignments/"), "/tags"), "/")
+		items := filterItems(f.tags, func(item map[string]interface{}) bool {
+			return lookup(item, "entity_type") == entityType && lookup(item, "entity_name") == entityName
+		})
+		f.listPage(w, query, "tag_assignments", items, "page_token", "next_page_token", "")
+	case path == "/api/2.1/unity-catalog/volumes":
+		if items, ok := f.childItems(w, f.schemas, f.volumes, query.Get("catalog_name"), query.Get("schema_name")); ok {
+			writeJSON(w, map[string]interface{}{"volumes": items})
+		}
+	case path == "/api/2.1/unity-catalog/functions":
+		if items, ok := f.childItems(w, f.schemas, f.functions, query.Get("catalog_name"), query.Get("schema_name")); ok {
+			writeJSON(w, map[string]interface{}{"functions": items})
+		}
+	case path == "/api/2.1/unity-catalog/external-locations":
+		writeJSON(w, map[string]interface{}{"external_locations": f.locations})
+	case path == "/api/2.1/unity-catalog/storage-credentials":
+		writeJSON(w, map[string]interface{}{"storage_credentials": f.creds})
+	case path == "/api/2.1/unity-catalog/connections":
+		writeJSON(w, map[string]interface{}{"connections": f.conns})
+	case path == "/api/2.1/unity-catalog/shares":
+		writeJSON(w, map[string]interface{}{"shares": f.shares})
+	case strings.HasPrefix(path, "/api/2.1/unity-catalog/permissions/"):
+		f.getGrants(w, strings.TrimPrefix(path, "/api/2.1/unity-catalog/permissions/"), query.Get("principal"), "privilege_assignments")
+	case strings.HasPrefix(path, "/api/2.1/unity-catalog/effective-permissions/"):
+		f.getGrants(w, strings.TrimPrefix(path, "/api/2.1/unity-catalog/effective-permissions/"), query.Get("principal"), "effective_privilege_assignments")
+	case path == "/api/2.0/lineage-tracking/table-lineage":
+		f.getLineage(w, query.Get("table_name"), "", "upstreams", "downstreams")
+	case path == "/api/2.0/lineage-tracking/column-lineage":
+		f.getLineage(w, query.Get("table_name"), query.Get("column_name"), "upstream_cols", "downstream_cols")
+	case path == "/.well-known/databricks-config":
+		writeJSON(w, map[string]interface{}{"account_id": fakeAccountId, "workspace_id": strconv.Itoa(fakeWorkspaceId)})
+	case path == "/api/2.1/unity-catalog/current-metastore-assignment":
+		writeJSON(w, map[string]interface{}{"workspace_id": fakeWorkspaceId, "metastore_id": "11111111-2222-3333-4444-555555555555"})
+
+	// SQL
+	case path == "/api/2.0/sql/warehouses":
+		writeJSON(w, map[string]interface{}{"warehouses": f.warehouses})
+	case path == "/api/2.0/sql/history/queries":
+		f.listPage(w, query, "res", filterQueryHistory(f.queries, query), "page_token", "next_page_token", "has_next_page")
+	case strings.HasPrefix(path, "/api/2.0/sql/warehouses/"):
+		f.getItem(w, f.warehouses, "id", strings.TrimPrefix(path, "/api/2.0/sql/warehouses/"), http.StatusNotFound, "RESOURCE_DOES_NOT_EXIST", "Warehouse %s does not exist.")
+
+	// Workspace
+	case path == "/api/2.0/repos":
+		items := filterItems(f.repos, func(item map[string]interface{}) bool {
+			return strings.HasPrefix(lookup(item, "path"), query.Get("path_prefix"))
+		})
+		f.listPage(w, query, "repos", items, "next_page_token", "next_page_token", "")
+	case strings.HasPrefix(path, "/api/2.0/repos/"):
+		f.getItem(w, f.repos, "id", strings.TrimPrefix(path, "/api/2.0/repos/"), http.StatusNotFound, "RESOURCE_DOES_NOT_EXIST", "Repo %s does not exist.")
+
+	default:
+		writeError(w, http.StatusNotFound, "ENDPOINT_NOT_FOUND", fmt.Sprintf("No API found for '%s %s'", r.Method, path))
+	}
+}
+
+// listSCIM serves a SCIM list, honouring the startIndex, count and filter
+// parameters.
+func (f *fakeDatabricks) listSCIM(w http.ResponseWriter, query url.Values, items []map[string]interface{}) {
+	filtered, err := scimFilter(items, query.Get("filter"))
+	if err != nil {
+		writeSCIMError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	start := 1
+	if v := query.Get("startIndex"); v != "" {
+		start, _ = strconv.Atoi(v)
+	}
+	count := len(filtered)
+	if v := query.Get("count"); v != "" {
+		count, _ = strconv.Atoi(v)
+	}
+
+	page := []map[string]interface{}{}
+	for i := start - 1; i >= 0 && i < len(filtered) && len(page) < count; i++ {
+		page = append(page, filtered[i])
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"schemas":      []string{"urn:ietf:params:scim:api:messages:2.0:ListResponse"},
+		"totalResults": len(filtered),
+		"startIndex":   start,
+		"itemsPerPage": len(page),
+		"Resources":    page,
+	})
+}
+
+func (f *fakeDatabricks) getSCIM(w http.ResponseWriter, items []map[string]interface{}, id string) {
+	for _, item := range items {
+		if lookup(item, "id") == id {
+			writeJSON(w, item)
+			return
+		}
+	}
+	writeSCIMError(w, http.StatusNotFound, fmt.Sprintf("User %s not found.", id))
+}
+
+// listPage serves a token paginated list. Pages hold at most pageSize items,
+// or the limit parameter if it is smaller. An optional hasMoreField is set
+// to true on every page but the last.
+func (f *fakeDatabricks) listPage(w http.ResponseWriter, query url.Values, key string, items []map[string]interface{}, tokenParam, nextTokenField, hasMoreField string) {
+	offset := 0
+	if token := query.Get(tokenParam); token != "" {
+		offset, _ = strconv.Atoi(strings.TrimPrefix(token, "page-"))
+	}
+	size := f.pageSize
+	if limit, err := strconv.Atoi(query.Get("limit")); err == nil && limit > 0 && limit < size {
+		size = limit
+	}
+	if limit, err := strconv.Atoi(query.Get("max_results")); err == nil && limit > 0 && limit < size {
+		size = limit
+	}
+
+	end := offset + size
+	if end > len(items) {
+		end = len(items)
+	}
+
+	response := map[string]interface{}{key: items[offset:end]}
+	if end < len(items) {
+		response[nextTokenField] = fmt.Sprintf("page-%d", end)
+		if hasMoreField != "" {
+			response[hasMoreField] = true
+		}
+	}
+	writeJSON(w, response)
+}
+
+// listClusterEvents serves the events of a cluster, newest first, honouring
+// the event_types, start_time and end_time filters and the offset and limit
+// pagination of the events API.
+func (f *fakeDatabricks) listClusterEvents(w http.ResponseWriter, body map[string]interface{}) {
+	clusterId := lookup(body, "cluster_id")
+	if !slices.ContainsFunc(f.clusters, func(item map[string]interface{}) bool { return lookup(item, "cluster_id") == clusterId }) {
+		writeError(w, http.StatusBadRequest, "INVALID_PARAMETER_VALUE", fmt.Sprintf("Cluster %s does not exist", clusterId))
+		return
+	}
+
+	eventTypes, _ := body["event_types"].([]interface{})
+	from, _ := strconv.ParseInt(lookup(body, "start_time"), 10, 64)
+	to, _ := strconv.ParseInt(lookup(body, "end_time"), 10, 64)
+	events := filterItems(f.events, func(item map[string]interface{}) bool {
+		timestamp, _ := strconv.ParseInt(lookup(item, "timestamp"), 10, 64)
+		return lookup(item, "cluster_id") == clusterId &&
+			(len(eventTypes) == 0 || slices.Contains(eventTypes, interface{}(lookup(item, "type")))) &&
+			(from == 0 || timestamp >= from) && (to == 0 || timestamp <= to)
+	})
+	slices.SortStableFunc(events, func(a, b map[string]interface{}) int {
+		return strings.Compare(lookup(b, "timestamp"), lookup(a, "timestamp"))
+	})
+
+	offset, _ := strconv.Atoi(lookup(body, "offset"))
+	size := f.pageSize
+	if limit, _ := strconv.Atoi(lookup(body, "limit")); limit > 0 && limit < size {
+		size = limit
+	}
+	end := min(offset+size, len(events))
+	offset = min(offset, end)
+
+	response := map[string]interface{}{"events": events[offset:end], "total_count": len(events)}
+	if end < len(events) {
+		nextPage := maps.Clone(body)
+		nextPage["offset"] = end
+		response["next_page"] = nextPage
+	}
+	writeJSON(w, response)
+}
+
+// getLineage serves the lineage of a table, or of one of its columns. Like
+// the real API, tables and columns without lineage have no upstreams or
+// downstreams, while unknown tables are not found.
+func (f *fakeDatabricks) getLineage(w http.ResponseWriter, tableName, columnName, upstreamsField, downstreamsField string) {
+	if strings.Count(tableName, ".") != 2 || strings.HasSuffix(tableName, ".missing") {
+		writeError(w, http.StatusNotFound, "TABLE_DOES_NOT_EXIST", fmt.Sprintf("Table '%s' does not exist.", tableName))
+		return
+	}
+	for _, item := range f.lineage {
+		if lookup(item, "table_name") == tableName && lookup(item, "column_name") == columnName {
+			writeJSON(w, map[string]interface{}{upstreamsField: item["upstreams"], downstreamsField: item["downstreams"]})
+			return
+		}
+	}
+	writeJSON(w, map[string]interface{}{})
+}
+
+// childItems returns the schemas of a catalog, or the tables, volumes or
+// functions of a schema. Like the real APIs, listing the children of an
+// unknown parent is an error, in which case it is written and false returned.
+func (f *fakeDatabricks) childItems(w http.ResponseWriter, parents, items []map[string]interface{}, catalogName string, schemaName ...string) ([]map[string]interface{}, bool) {
+	parentName := strings.Join(append([]string{catalogName}, schemaName...), ".")
+	if !slices.ContainsFunc(parents, func(item map[string]interface{}) bool { return lookup(item, "full_name") == parentName }) {
+		if len(schemaName) == 0 {
+			writeError(w, http.StatusNotFound, "CATALOG_DOES_NOT_EXIST", fmt.Sprintf("Catalog '%s' does not exist.", parentName))
+		} else {
+			writeError(w, http.StatusNotFound, "SCHEMA_DOES_NOT_EXIST", fmt.Sprintf("Schema '%s' does not exist.", parentName))
+		}
+		return nil, false
+	}
+	return filterItems(items, func(item map[string]interface{}) bool {
+		return strings.HasPrefix(lookup(item, "full_name"), parentName+".")
+	}), true
+}
+
+// likePattern converts a SQL LIKE pattern into a regular expression. An
+// empty pattern matches everything.
+func likePattern(pattern string) *regexp.Regexp {
+	if pattern == "" {
+		pattern = "%"
+	}
+	expression := regexp.QuoteMeta(pattern)
+	expression = strings.NewReplacer("%", ".*", "_", ".").Replace(expression)
+	return regexp.MustCompile("^" + expression + "$")
+}
+
+// getGrants serves the direct or effective privilege assignments of a
+// securable, optionally only those of a principal. Securables without a
+// fixture are not found.
+func (f *fakeDatabricks) getGrants(w http.ResponseWriter, securable, principal, assignmentsField string) {
+	securableType, fullName, _ := strings.Cut(securable, "/")
+	if f.permissionDenied[fullName] {
+		writeError(w, http.StatusForbidden, "PERMISSION_DENIED", fmt.Sprintf("User does not have MANAGE on %s '%s'.", securableType, fullName))
+		return
+	}
+	for _, item := range f.grants {
+		if lookup(item, "securable_type") != securableType || lookup(item, "full_name") != fullName {
+			continue
+		}
+		assignments, _ := item[assignmentsField].([]interface{})
+		assignments = slices.DeleteFunc(slices.Clone(assignments), func(assignment interface{}) bool {
+			return principal != "" && lookup(assignment.(map[string]interface{}), "principal") != principal
+		})
+		writeJSON(w, map[string]interface{}{"privilege_assignments": assignments})
+		return
+	}
+	writeError(w, http.StatusNotFound, strings.ToUpper(securableType)+"_DOES_NOT_EXIST", fmt.Sprintf("%s '%s' does not exist.", securableType, fullName))
+}
+
+// getRunOutput serves the output of a task run. Like the real API, it rejects
+// job runs with multiple tasks.
+func (f *fakeDatabricks) getRunOutput(w http.ResponseWriter, runId string) {
+	if f.pendingOutputs[runId] {
+		writeError(w, http.StatusBadRequest, "INVALID_STATE", fmt.Sprintf("Run %s has not started yet.", runId))
+		return
+	}
+	for _, run := range f.runs {
+		if lookup(run, "run_id") == runId {
+			if tasks, _ := run["tasks"].([]interface{}); len(tasks) > 1 {
+				writeError(w, http.StatusBadRequest, "INVALID_PARAMETER_VALUE", "Retrieving the output of runs with multiple tasks is not supported. Please retrieve the output of each individual task run instead.")
+				return
+			}
+		}
+	}
+	f.getItem(w, f.outputs, "run_id", runId, http.StatusBadRequest, "INVALID_PARAMETER_VALUE", "Run %s does not exist.")
+}
+
+func (f *fakeDatabricks) getItem(w http.ResponseWriter, items []map[string]interface{}, idField, id string, status int, errorCode, messageFormat string) {
+	for _, item := range items {
+		if lookup(item, idField) == id {
+			writeJSON(w, item)
+			return
+		}
+	}
+	writeError(w, status, errorCode, fmt.Sprintf(messageFormat, id))
+}
+
+func (f *fakeDatabricks) getPermissions(w http.ResponseWriter, path string) {
+	parts := strings.Split(strings.TrimPrefix(path, "/api/2.0/permissions/"), "/")
+	if len(parts) != 2 {
+		writeError(w, http.StatusNotFound, "ENDPOINT_NOT_FOUND", fmt.Sprintf("No API found for 'GET %s'", path))
+		return
+	}
+	objectType, id := parts[0], parts[1]
+
+	if f.permissionDenied[id] {
+		writeError(w, http.StatusForbidden, "PERMISSION_DENIED", fmt.Sprintf("User does not have permission to view permissions of %s %s", objectType, id))
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"object_id":   fmt.Sprintf("/%s/%s", objectType, id),
+		"object_type": strings.TrimSuffix(objectType, "s"),
+		"access_control_list": []map[string]interface{}{
+			{
+				"group_name": "admins",
+				"all_permissions": []map[string]interface{}{
+					{"permission_level": "CAN_MANAGE", "inherited": true},
+				},
+			},
+		},
+	})
+}
+
+// scimFilter applies a filter of "and" joined expressions, each of which is
+// either "attribute op value", "attribute pr" or a parenthesised group of "or"
+// joined expressions, e.g. userName sw "a" and (active eq true or id eq "1").
+func scimFilter(items []map[string]interface{}, filter string) ([]map[string]interface{}, error) {
+	if filter == "" {
+		return items, nil
+	}
+
+	var groups [][]scimExpression
+	for _, term := range splitOutsideQuotes(filter, " and ") {
+		var group []scimExpression
+		for _, part := range splitOutsideQuotes(strings.TrimSuffix(strings.TrimPrefix(term, "("), ")"), " or ") {
+			expression, err := parseSCIMExpression(part)
+			if err != nil {
+				return nil, err
+			}
+			group = append(group, expression)
+		}
+		groups = append(groups, group)
+	}
+
+	return filterItems(items, func(item map[string]interface{}) bool {
+		for _, group := range groups {
+			matched := false
+			for _, expression := range group {
+				if expression.matches(item) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return false
+			}
+		}
+		return true
+	}), nil
+}
+
+type scimExpression struct {
+	attribute, operator, value string
+}
+
+var scimExpressionPattern = regexp.MustCompile(`^(\S+) (eq|ne|co|sw|gt|ge|lt|le) ("(?:[^"\\]|\\.)*"|true|false)$|^(\S+) pr$`)
+
+func parseSCIMExpression(expression string) (scimExpression, error) {
+	match := scimExpressionPattern.FindStringSubmatch(strings.TrimSpace(expression))
+	if match == nil {
+		return scimExpression{}, fmt.Errorf("unsupported filter expression %q", expression)
+	}
+	if match[4] != "" {
+		return scimExpression{attribute: match[4], operator: "pr"}, nil
+	}
+	value := match[3]
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	}
+	return scimExpression{attribute: match[1], operator: match[2], value: value}, nil
+}
+
+func (e scimExpression) matches(item map[string]interface{}) bool {
+	actual := lookup(item, strings.Split(e.attribute, ".")...)
+	switch e.operator {
+	case "pr":
+		return actual != ""
+	case "eq":
+		return strings.EqualFold(actual, e.value)
+	case "ne":
+		return !strings.EqualFold(actual, e.value)
+	case "co":
+		return strings.Contains(strings.ToLower(actual), strings.ToLower(e.value))
+	case "sw":
+		return strings.HasPrefix(strings.ToLower(actual), strings.ToLower(e.value))
+	case "gt":
+		return actual > e.value
+	case "ge":
+		return actual >= e.value
+	case "lt":
+		return actual < e.value
+	case "le":
+		return actual <= e.value
+	}
+	return false
+}
+
+// splitOutsideQuotes splits s around each separator which is not inside a
+// quoted string.
+func splitOutsideQuotes(s, separator string) []string {
+	var parts []string
+	inQuotes := false
+	start := 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && inQuotes:
+			i++
+		case s[i] == '"':
+			inQuotes = !inQuotes
+		case !inQuotes && strings.HasPrefix(s[i:], separator):
+			parts = append(parts, s[start:i])
+			start = i + len(separator)
+			i += len(separator) - 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// filterRuns applies the job_id, time window and state filters of the runs
+// list API.
+func filterRuns(runs []map[string]interface{}, query url.Values) []map[string]interface{} {
+	from, _ := strconv.ParseInt(query.Get("start_time_from"), 10, 64)
+	to, _ := strconv.ParseInt(query.Get("start_time_to"), 10, 64)
+	return filterItems(runs, func(item map[string]interface{}) bool {
+		if jobId := query.Get("job_id"); jobId != "" && lookup(item, "job_id") != jobId {
+			return false
+		}
+		startTime, _ := strconv.ParseInt(lookup(item, "start_time"), 10, 64)
+		if (from > 0 && startTime < from) || (to > 0 && startTime > to) {
+			return false
+		}
+		switch lookup(item, "state", "life_cycle_state") {
+		case "PENDING", "RUNNING", "TERMINATING":
+			return query.Get("completed_only") != "true"
+		default:
+			return query.Get("active_only") != "true"
+		}
+	})
+}
+
+// filterQueryHistory applies the filter_by parameters of the query history
+// API, and drops the metrics unless include_metrics is set.
+func filterQueryHistory(queries []map[string]interface{}, query url.Values) []map[string]interface{} {
+	matches := func(param, value string) bool {
+		values := query["filter_by."+param]
+		return len(values) == 0 || slices.Contains(values, value)
+	}
+	from, _ := strconv.ParseInt(query.Get("filter_by.query_start_time_range.start_time_ms"), 10, 64)
+	to, _ := strconv.ParseInt(query.Get("filter_by.query_start_time_range.end_time_ms"), 10, 64)
+
+	var results []map[string]interface{}
+	for _, item := range queries {
+		startTime, _ := strconv.ParseInt(lookup(item, "query_start_time_ms"), 10, 64)
+		if !matches("warehouse_ids", lookup(item, "warehouse_id")) ||
+			!matches("user_ids", lookup(item, "user_id")) ||
+			!matches("statuses", lookup(item, "status")) ||
+			(from > 0 && startTime < from) || (to > 0 && startTime > to) {
+			continue
+		}
+		result := maps.Clone(item)
+		if query.Get("include_metrics") != "true" {
+			delete(result, "metrics")
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+func filterItems(items []map[string]interface{}, keep func(map[string]interface{}) bool) []map[string]interface{} {
+	filtered := []map[string]interface{}{}
+	for _, item := range items {
+		if keep(item) {
+			filtered = append(filtered, item)
+		}
+	}
+	return filtered
+}
+
+// lookup returns the string form of a nested fixture field.
+func lookup(item map[string]interface{}, keys ...string) string {
+	var value interface{} = item
+	for _, key := range keys {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return ""
+		}
+		value = m[key]
+	}
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, status int, errorCode, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"error_code": errorCode,
+		"message":    message,
+	})
+}
+
+func writeSCIMError(w http.ResponseWriter, status int, detail string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{
+		"schemas": []string{"urn:ietf:params:scim:api:messages:2.0:Error"},
+		"detail":  detail,
+		"status":  strconv.Itoa(status),
+	})
+}
@@ -0,0 +1,170 @@
+package databricks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go/service/provisioning"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+const (
+	matrixKeyWorkspaceId   = "workspace_id"
+	matrixKeyWorkspaceHost = "workspace_host"
+	// Set instead of the workspace keys when the workspaces to aggregate
+	// cannot be listed
+	matrixKeyWorkspaceError = "workspace_error"
+)
+
+// workspaceMatrix returns a matrix item per workspace of the account when
+// aggregate_workspaces is enabled for the connection. Otherwise no matrix is
+// returned and the table is queried using the single configured workspace.
+// If the workspaces cannot be listed, a single item carrying the error is
+// returned, so that the query fails rather than silently falling back to the
+// configured workspace.
+func workspaceMatrix(ctx context.Context, d *plugin.QueryData) []map[string]interface{} {
+	config := GetConfig(d.Connection)
+	if config.AggregateWorkspaces == nil || !*config.AggregateWorkspaces {
+		return nil
+	}
+
+	workspaces, err := listAccountWorkspacesCached(ctx, d, nil)
+	if err != nil {
+		plugin.Logger(ctx).Error("workspaceMatrix", "connection_name", d.Connection.Name, "list_workspaces_error", err)
+		return []map[string]interface{}{
+			{matrixKeyWorkspaceError: fmt.Sprintf("unable to list the workspaces to aggregate: %s", err)},
+		}
+	}
+
+	matrix := []map[string]interface{}{}
+	for _, workspace := range workspaces.([]accountWorkspace) {
+		matrix = append(matrix, map[string]interface{}{
+			matrixKeyWorkspaceId:   workspace.WorkspaceId,
+			matrixKeyWorkspaceHost: workspace.Host,
+		})
+	}
+
+	return matrix
+}
+
+type accountWorkspace struct {
+	WorkspaceId   int64
+	WorkspaceName string
+	Host          string
+}
+
+// Cached form of listAccountWorkspaces, the workspaces of an account rarely
+// change and the list is needed for every workspace-level table.
+var listAccountWorkspacesCached = plugin.HydrateFunc(listAccountWorkspacesUncached).Memoize()
+
+func listAccountWorkspacesUncached(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	config := GetConfig(d.Connection)
+
+	client, err := getAccountClient(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	// Azure Databricks accounts do not list their workspaces through the
+	// account API
+	if client.Config.IsAzure() {
+		return nil, errors.New("aggregate_workspaces is only supported for Databricks on AWS and Google Cloud")
+	}
+
+	workspaces, err := client.Workspaces.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	domain, err := workspaceDomainFromAccountHost(client.Config.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []accountWorkspace
+	for _, workspace := range workspaces {
+		// Workspaces which are still provisioning or have failed cannot be queried
+		if workspace.WorkspaceStatus != provisioning.WorkspaceStatusRunning {
+			continue
+		}
+		if !shouldIncludeWorkspace(config, workspace) {
+			continue
+		}
+		items = append(items, accountWorkspace{
+			WorkspaceId:   workspace.WorkspaceId,
+			WorkspaceName: workspace.WorkspaceName,
+			Host:          fmt.Sprintf("https://%s.%s", workspace.DeploymentName, domain),
+		})
+	}
+
+	return items, nil
+}
+
+// shouldIncludeWorkspace applies the include_workspaces and
+// exclude_workspaces connection filters. Each filter matches either the
+// workspace name or the workspace ID and supports wildcards, e.g. "prod-*".
+func shouldIncludeWorkspace(config databricksConfig, workspace provisioning.Workspace) bool {
+	if len(config.IncludeWorkspaces) > 0 && !workspaceMatchesAny(config.IncludeWorkspaces, workspace) {
+		return false
+	}
+	return !workspaceMatchesAny(config.ExcludeWorkspaces, workspace)
+}
+
+func workspaceMatchesAny(patterns []string, workspace provisioning.Workspace) bool {
+	id := strconv.FormatInt(workspace.WorkspaceId, 10)
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, workspace.WorkspaceName); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, id); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// workspaceDomainFromAccountHost derives the workspace domain from the
+// account console host, e.g. https://accounts.cloud.databricks.com gives
+// cloud.databricks.com, so that the workspace host is
+// https://<deployment_name>.cloud.databricks.com.
+func workspaceDomainFromAccountHost(accountHost string) (string, error) {
+	u, err := url.Parse(accountHost)
+	if err != nil {
+		return "", err
+	}
+	domain, found := strings.CutPrefix(u.Hostname(), "accounts.")
+	if !found {
+		return "", fmt.Errorf("unable to derive workspace hosts from account host %q", accountHost)
+	}
+	return domain, nil
+}
+
+// deploymentNameFromHost returns the first label of the workspace host, e.g.
+// https://dbc-a1b2c3d4-e6f7.cloud.databricks.com gives dbc-a1b2c3d4-e6f7.
+func deploymentNameFromHost(host string) string {
+	u, err := url.Parse(host)
+	if err != nil {
+		return ""
+	}
+	name, _, _ := strings.Cut(u.Hostname(), ".")
+	return name
+}
+
+// workspaceIdFromHost extracts the workspace ID from Azure
+// (adb-<workspace_id>.<n>.azuredatabricks.net) and Google Cloud
+// (<workspace_id>.<n>.gcp.databricks.com) workspace hosts. AWS workspace
+// hosts do not include the workspace ID, so 0 is returned.
+func workspaceIdFromHost(host string) int64 {
+	name := deploymentNameFromHost(host)
+	name = strings.TrimPrefix(name, "adb-")
+	id, err := strconv.ParseInt(name, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return id
+}
@@ -0,0 +1,37 @@
+package databricks
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWorkspaceMatrixListError(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	// Without an account host, the workspaces of the account cannot be listed
+	p := newTestPlugin(t, fake, "aggregate_workspaces = true")
+
+	_, err := p.tryQuery("databricks_job", []string{"job_id"}, nil, 0)
+	if err == nil || !strings.Contains(err.Error(), "unable to list the workspaces to aggregate") {
+		t.Errorf("got error %v, want the workspace listing error", err)
+	}
+	if got := len(fake.requestsTo("/api/2.1/jobs/list")); got != 0 {
+		t.Errorf("got %d requests to list jobs of the configured workspace, want 0", got)
+	}
+}
+
+func TestWorkspaceDomainFromAccountHost(t *testing.T) {
+	for _, test := range []struct {
+		accountHost, want string
+	}{
+		{"https://accounts.cloud.databricks.com", "cloud.databricks.com"},
+		{"https://accounts.gcp.databricks.com", "gcp.databricks.com"},
+	} {
+		got, err := workspaceDomainFromAccountHost(test.accountHost)
+		if err != nil || got != test.want {
+			t.Errorf("workspaceDomainFromAccountHost(%q) = %q, %v, want %q", test.accountHost, got, err, test.want)
+		}
+	}
+	if _, err := workspaceDomainFromAccountHost("https://dbc-a1b2c3d4-e6f7.cloud.databricks.com"); err == nil {
+		t.Errorf("expected an error for a workspace host")
+	}
+}
@@ -0,0 +1,269 @@
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/anywhere"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	fakeToken       = "dapi-test-token"
+	fakeAccountId   = "abcdd0f8-9be0-4425-9e29-3a7d96782373"
+	fakeWorkspaceId = 1234567890123456
+)
+
+func TestMain(m *testing.M) {
+	// Keep the developer's Databricks profile and environment out of the
+	// tests, the connection config is the only source of credentials
+	home, err := os.MkdirTemp("", "databricks-test-home")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Setenv("HOME", home)
+	for _, env := range os.Environ() {
+		if name, _, _ := strings.Cut(env, "="); strings.HasPrefix(name, "DATABRICKS_") || strings.HasPrefix(name, "ARM_") || strings.HasPrefix(name, "GOOGLE_") {
+			os.Unsetenv(name)
+		}
+	}
+
+	code := m.Run()
+	os.RemoveAll(home)
+	os.Exit(code)
+}
+
+// testPlugin runs queries against an in-process plugin server, so that each
+// query goes through a real plugin.QueryData, including key column
+// resolution, limits, ignore configs and hydrate dependencies.
+type testPlugin struct {
+	server     *grpc.PluginServer
+	connection string
+}
+
+var (
+	testCallId       atomic.Int64
+	testConnectionId atomic.Int64
+
+	// The plugin server shared by the tests of the package. A plugin server
+	// and its query cache cannot be shut down, so rather than starting one
+	// per test, each test adds its own connection to the shared server.
+	testServer     *grpc.PluginServer
+	testServerErr  error
+	testServerOnce sync.Once
+)
+
+func sharedTestServer(t *testing.T) *grpc.PluginServer {
+	t.Helper()
+
+	testServerOnce.Do(func() {
+		testServer = plugin.Server(&plugin.ServeOpts{PluginFunc: Plugin})
+		_, testServerErr = testServer.SetAllConnectionConfigs(&proto.SetAllConnectionConfigsRequest{
+			MaxCacheSizeMb: 16,
+		})
+	})
+	if testServerErr != nil {
+		t.Fatalf("starting plugin server: %v", testServerErr)
+	}
+	return testServer
+}
+
+// newTestPlugin adds a connection pointed at the fake server to the shared
+// plugin server, and removes it once the test completes. Extra connection
+// config arguments can be passed as HCL.
+func newTestPlugin(t *testing.T, fake *fakeDatabricks, extraConfig ...string) *testPlugin {
+	t.Helper()
+
+	return newTestPluginWithConfig(t, fmt.Sprintf(`
+workspace_host  = %q
+workspace_token = %q
+account_id      = %q
+%s
+`, fake.URL, fakeToken, fakeAccountId, strings.Join(extraConfig, "\n")))
+}
+
+// newWorkspaceTestPlugin is like newTestPlugin, but for a workspace-only
+// connection which does not set the account ID.
+func newWorkspaceTestPlugin(t *testing.T, fake *fakeDatabricks) *testPlugin {
+	t.Helper()
+
+	return newTestPluginWithConfig(t, fmt.Sprintf(`
+workspace_host  = %q
+workspace_token = %q
+`, fake.URL, fakeToken))
+}
+
+func newTestPluginWithConfig(t *testing.T, config string) *testPlugin {
+	t.Helper()
+
+	server := sharedTestServer(t)
+	connection := &proto.ConnectionConfig{
+		Connection:      fmt.Sprintf("databricks_test_%d", testConnectionId.Add(1)),
+		Plugin:          "hub.steampipe.io/plugins/turbot/databricks@latest",
+		PluginShortName: pluginName,
+		Config:          config,
+	}
+	if _, err := server.UpdateConnectionConfigs(&proto.UpdateConnectionConfigsRequest{Added: []*proto.ConnectionConfig{connection}}); err != nil {
+		t.Fatalf("adding connection: %v", err)
+	}
+	t.Cleanup(func() {
+		server.UpdateConnectionConfigs(&proto.UpdateConnectionConfigsRequest{Deleted: []*proto.ConnectionConfig{connection}})
+	})
+
+	return &testPlugin{server: server, connection: connection.Connection}
+}
+
+// query executes a query for the given columns and quals and returns the
+// rows keyed by column name. A limit of 0 means no limit.
+func (p *testPlugin) query(t *testing.T, table string, columns []string, quals []*proto.Qual, limit int64) []map[string]interface{} {
+	t.Helper()
+
+	rows, err := p.tryQuery(table, columns, quals, limit)
+	if err != nil {
+		t.Fatalf("querying %s: %v", table, err)
+	}
+	return rows
+}
+
+func (p *testPlugin) tryQuery(table string, columns []string, quals []*proto.Qual, limit int64) ([]map[string]interface{}, error) {
+	queryContext := &proto.QueryContext{
+		Columns: columns,
+		Quals:   map[string]*proto.Quals{},
+	}
+	for _, qual := range quals {
+		if queryContext.Quals[qual.FieldName] == nil {
+			queryContext.Quals[qual.FieldName] = &proto.Quals{}
+		}
+		queryContext.Quals[qual.FieldName].Quals = append(queryContext.Quals[qual.FieldName].Quals, qual)
+	}
+
+	executeData := &proto.ExecuteConnectionData{}
+	if limit > 0 {
+		queryContext.Limit = &proto.NullableInt{Value: limit}
+		executeData.Limit = queryContext.Limit
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	stream := anywhere.NewLocalPluginStream(ctx)
+	p.server.CallExecuteAsync(&proto.ExecuteRequest{
+		Table:        table,
+		QueryContext: queryContext,
+		CallId:       fmt.Sprintf("test-%d", testCallId.Add(1)),
+		ExecuteConnectionData: map[string]*proto.ExecuteConnectionData{
+			p.connection: executeData,
+		},
+	}, stream)
+
+	var rows []map[string]interface{}
+	for {
+		response, err := stream.Recv()
+		if err != nil {
+			return nil, err
+		}
+		if response == nil {
+			return rows, nil
+		}
+		if response.Row == nil {
+			continue
+		}
+		row := map[string]interface{}{}
+		for name, column := range response.Row.Columns {
+			row[name] = columnValue(column)
+		}
+		rows = append(rows, row)
+	}
+}
+
+func columnValue(column *proto.Column) interface{} {
+	switch v := column.Value.(type) {
+	case *proto.Column_StringValue:
+		return v.StringValue
+	case *proto.Column_IntValue:
+		return v.IntValue
+	case *proto.Column_DoubleValue:
+		return v.DoubleValue
+	case *proto.Column_BoolValue:
+		return v.BoolValue
+	case *proto.Column_TimestampValue:
+		return v.TimestampValue.AsTime()
+	case *proto.Column_JsonValue:
+		var value interface{}
+		if err := json.Unmarshal(v.JsonValue, &value); err != nil {
+			return string(v.JsonValue)
+		}
+		return value
+	default:
+		return nil
+	}
+}
+
+// qual builds a qual for a string, int64, bool, time.Time or []string (IN)
+// value.
+func qual(column, operator string, value interface{}) *proto.Qual {
+	return &proto.Qual{
+		FieldName: column,
+		Operator:  &proto.Qual_StringValue{StringValue: operator},
+		Value:     qualValue(value),
+	}
+}
+
+func qualValue(value interface{}) *proto.QualValue {
+	switch v := value.(type) {
+	case string:
+		return &proto.QualValue{Value: &proto.QualValue_StringValue{StringValue: v}}
+	case int:
+		return &proto.QualValue{Value: &proto.QualValue_Int64Value{Int64Value: int64(v)}}
+	case int64:
+		return &proto.QualValue{Value: &proto.QualValue_Int64Value{Int64Value: v}}
+	case bool:
+		return &proto.QualValue{Value: &proto.QualValue_BoolValue{BoolValue: v}}
+	case time.Time:
+		return &proto.QualValue{Value: &proto.QualValue_TimestampValue{TimestampValue: timestamppb.New(v)}}
+	case []string:
+		list := &proto.QualValueList{}
+		for _, item := range v {
+			list.Values = append(list.Values, qualValue(item))
+		}
+		return &proto.QualValue{Value: &proto.QualValue_ListValue{ListValue: list}}
+	default:
+		panic(fmt.Sprintf("unsupported qual value type %T", value))
+	}
+}
+
+// columnStrings returns the sorted string values of a column across rows,
+// rows are streamed concurrently so their order is not stable.
+func columnStrings(rows []map[string]interface{}, column string) []string {
+	var values []string
+	for _, row := range rows {
+		values = append(values, fmt.Sprint(row[column]))
+	}
+	sort.Strings(values)
+	return values
+}
+
+// rowWith returns the first row whose column has the given string value.
+func rowWith(t *testing.T, rows []map[string]interface{}, column, value string) map[string]interface{} {
+	t.Helper()
+
+	for _, row := range rows {
+		if fmt.Sprint(row[column]) == value {
+			return row
+		}
+	}
+	t.Fatalf("no row with %s = %q", column, value)
+	return nil
+}
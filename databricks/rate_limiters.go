@@ -0,0 +1,83 @@
+package databricks
+
+import (
+	"github.com/turbot/steampipe-plugin-sdk/v5/rate_limiter"
+)
+
+// rateLimiters returns the default client-side rate limiters, one per
+// Databricks service family. Each list, get and hydrate config is tagged with
+// the service it calls, e.g. "service": "unity_catalog", and a limiter
+// instance is created per connection and service.
+//
+// The defaults stay below the documented per-workspace API limits. They are
+// not connection arguments, so they cannot be overridden per connection, only
+// with limiter blocks in the plugin config, which apply to every connection.
+// The overall request rate of a connection is capped by the rate_limit
+// connection argument.
+func rateLimiters() []*rate_limiter.Definition {
+	return []*rate_limiter.Definition{
+		{
+			Name:       "databricks_unity_catalog",
+			FillRate:   10,
+			BucketSize: 10,
+			Scope:      []string{"connection", "service"},
+			Where:      "service = 'unity_catalog'",
+		},
+		{
+			Name:       "databricks_scim",
+			FillRate:   5,
+			BucketSize: 5,
+			Scope:      []string{"connection", "service"},
+			Where:      "service = 'scim'",
+		},
+		{
+			Name:       "databricks_jobs",
+			FillRate:   10,
+			BucketSize: 10,
+			Scope:      []string{"connection", "service"},
+			Where:      "service = 'jobs'",
+		},
+		{
+			Name:       "databricks_sql",
+			FillRate:   10,
+			BucketSize: 10,
+			Scope:      []string{"connection", "service"},
+			Where:      "service = 'sql'",
+		},
+		{
+			Name:       "databricks_workspace",
+			FillRate:   10,
+			BucketSize: 10,
+			Scope:      []string{"connection", "service"},
+			Where:      "service = 'workspace'",
+		},
+		{
+			Name:       "databricks_compute",
+			FillRate:   10,
+			BucketSize: 10,
+			Scope:      []string{"connection", "service"},
+			Where:      "service = 'compute'",
+		},
+		{
+			Name:       "databricks_pipelines",
+			FillRate:   5,
+			BucketSize: 5,
+			Scope:      []string{"connection", "service"},
+			Where:      "service = 'pipelines'",
+		},
+		{
+			Name:       "databricks_ml",
+			FillRate:   10,
+			BucketSize: 10,
+			Scope:      []string{"connection", "service"},
+			Where:      "service = 'ml'",
+		},
+		{
+			Name:       "databricks_settings",
+			FillRate:   5,
+			BucketSize: 5,
+			Scope:      []string{"connection", "service"},
+			Where:      "service = 'settings'",
+		},
+	}
+}
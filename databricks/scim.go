@@ -0,0 +1,103 @@
+package databricks
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/databricks/databricks-sdk-go/service/iam"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+// scimResourceMeta is the SCIM metadata of a user, group or service
+// principal. The SDK only models the resource type, so the workspace IAM
+// tables decode the SCIM resources themselves to expose when each was created.
+type scimResourceMeta struct {
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	ResourceType string `json:"resourceType,omitempty"`
+}
+
+type scimUser struct {
+	iam.User
+	Meta *scimResourceMeta `json:"meta,omitempty"`
+}
+
+type scimGroup struct {
+	iam.Group
+	Meta *scimResourceMeta `json:"meta,omitempty"`
+}
+
+type scimServicePrincipal struct {
+	iam.ServicePrincipal
+	Meta *scimResourceMeta `json:"meta,omitempty"`
+}
+
+type scimListResponse[T any] struct {
+	ItemsPerPage int64 `json:"itemsPerPage,omitempty"`
+	Resources    []T   `json:"Resources,omitempty"`
+	StartIndex   int64 `json:"startIndex,omitempty"`
+	TotalResults int64 `json:"totalResults,omitempty"`
+}
+
+// listSCIMResources streams the workspace SCIM resources at the given path,
+// filtered on the quals of the given attributes. The limit of the query is
+// passed as the page size, so that a limited query fetches a single page.
+func listSCIMResources[T any](ctx context.Context, d *plugin.QueryData, path string, attributes []scimFilterAttribute) error {
+	// Limiting the results
+	maxLimit := int64(10000)
+	if d.QueryContext.Limit != nil {
+		limit := *d.QueryContext.Limit
+		if limit < maxLimit {
+			maxLimit = limit
+		}
+	}
+
+	client, err := getWorkspaceAPIClient(ctx, d)
+	if err != nil {
+		return err
+	}
+
+	params := url.Values{}
+	params.Set("count", strconv.FormatInt(maxLimit, 10))
+	if filter := buildSCIMFilter(attributes, d.Quals); filter != "" {
+		params.Set("filter", filter)
+	}
+
+	for startIndex := int64(1); ; startIndex += maxLimit {
+		params.Set("startIndex", strconv.FormatInt(startIndex, 10))
+
+		var response scimListResponse[T]
+		if err := client.Do(ctx, http.MethodGet, path+"?"+params.Encode(), nil, &response); err != nil {
+			return err
+		}
+
+		for _, item := range response.Resources {
+			d.StreamListItem(ctx, item)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+
+		if response.ItemsPerPage < maxLimit {
+			return nil
+		}
+	}
+}
+
+// getSCIMResource gets a single workspace SCIM resource by its ID.
+func getSCIMResource[T any](ctx context.Context, d *plugin.QueryData, path string, id string) (*T, error) {
+	client, err := getWorkspaceAPIClient(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	var resource T
+	if err := client.Do(ctx, http.MethodGet, path+"/"+url.PathEscape(id), nil, &resource); err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
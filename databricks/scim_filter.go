@@ -0,0 +1,211 @@
+package databricks
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/quals"
+)
+
+// scimFilterAttribute maps a key column of an IAM table to the SCIM
+// attribute it is filtered on.
+type scimFilterAttribute struct {
+	Column    string
+	Attribute string
+	Type      proto.ColumnType
+}
+
+// Filterable attributes of the workspace and account SCIM resources
+var (
+	scimUserFilterAttributes = []scimFilterAttribute{
+		{"id", "id", proto.ColumnType_STRING},
+		{"user_name", "userName", proto.ColumnType_STRING},
+		{"display_name", "displayName", proto.ColumnType_STRING},
+		{"external_id", "externalId", proto.ColumnType_STRING},
+		{"active", "active", proto.ColumnType_BOOL},
+	}
+	scimGroupFilterAttributes = []scimFilterAttribute{
+		{"display_name", "displayName", proto.ColumnType_STRING},
+		{"external_id", "externalId", proto.ColumnType_STRING},
+	}
+	scimServicePrincipalFilterAttributes = []scimFilterAttribute{
+		{"display_name", "displayName", proto.ColumnType_STRING},
+		{"application_id", "applicationId", proto.ColumnType_STRING},
+		{"external_id", "externalId", proto.ColumnType_STRING},
+		{"active", "active", proto.ColumnType_BOOL},
+	}
+
+	// The workspace tables can also be filtered on the creation time
+	scimCreatedFilterAttribute                    = scimFilterAttribute{"created", "meta.created", proto.ColumnType_TIMESTAMP}
+	scimWorkspaceUserFilterAttributes             = append(slices.Clone(scimUserFilterAttributes), scimCreatedFilterAttribute)
+	scimWorkspaceGroupFilterAttributes            = append(slices.Clone(scimGroupFilterAttributes), scimCreatedFilterAttribute)
+	scimWorkspaceServicePrincipalFilterAttributes = append(slices.Clone(scimServicePrincipalFilterAttributes), scimCreatedFilterAttribute)
+)
+
+// scimCaseInsensitiveAttributes are compared case-insensitively by SCIM, so
+// only the operators which match a superset of the Postgres rows, i.e. eq, sw,
+// co and pr, can be pushed down for them.
+var scimCaseInsensitiveAttributes = []string{"userName", "displayName", "emails"}
+
+// SCIM comparison operators for each Postgres operator
+var scimComparisonOperators = map[string]string{
+	quals.QualOperatorEqual:          "eq",
+	quals.QualOperatorNotEqual:       "ne",
+	quals.QualOperatorGreater:        "gt",
+	quals.QualOperatorGreaterOrEqual: "ge",
+	quals.QualOperatorLess:           "lt",
+	quals.QualOperatorLessOrEqual:    "le",
+}
+
+// scimKeyColumns returns optional list key columns for the given attributes,
+// with the operators that can be pushed down for each attribute type.
+func scimKeyColumns(attributes []scimFilterAttribute) []*plugin.KeyColumn {
+	var keyColumns []*plugin.KeyColumn
+	for _, attribute := range attributes {
+		operators := []string{"=", "<>"}
+		switch {
+		case attribute.Type == proto.ColumnType_STRING && slices.Contains(scimCaseInsensitiveAttributes, attribute.Attribute):
+			operators = []string{"=", "~~", "is not null"}
+		case attribute.Type == proto.ColumnType_STRING:
+			operators = append(operators, ">", ">=", "<", "<=", "~~", "is not null")
+		case attribute.Type == proto.ColumnType_TIMESTAMP:
+			operators = append(operators, ">", ">=", "<", "<=", "is not null")
+		}
+		keyColumns = append(keyColumns, &plugin.KeyColumn{
+			Name:      attribute.Column,
+			Require:   plugin.Optional,
+			Operators: operators,
+		})
+	}
+	return keyColumns
+}
+
+// buildSCIMFilter translates the quals on the given attributes into a SCIM
+// filter, e.g. userName sw "admin" and active eq true. Quals which cannot be
+// expressed in SCIM are left out, Postgres filters the returned rows anyway.
+func buildSCIMFilter(attributes []scimFilterAttribute, keyColumnQuals plugin.KeyColumnQualMap) string {
+	var expressions []string
+	for _, attribute := range attributes {
+		columnQuals := keyColumnQuals[attribute.Column]
+		if columnQuals == nil {
+			continue
+		}
+		for _, qual := range columnQuals.Quals {
+			if expression, ok := scimFilterExpression(attribute, qual); ok {
+				expressions = append(expressions, expression)
+			}
+		}
+	}
+	return strings.Join(expressions, " and ")
+}
+
+func scimFilterExpression(attribute scimFilterAttribute, qual *quals.Qual) (string, bool) {
+	if qual.Operator == quals.QualOperatorIsNotNull {
+		return attribute.Attribute + " pr", true
+	}
+	if qual.Value == nil {
+		return "", false
+	}
+
+	// An IN list becomes a group of equality expressions
+	if list := qual.Value.GetListValue(); list != nil {
+		if qual.Operator != quals.QualOperatorEqual || len(list.Values) == 0 {
+			return "", false
+		}
+		var terms []string
+		for _, item := range list.Values {
+			value, ok := scimFilterValue(attribute.Type, item)
+			if !ok {
+				return "", false
+			}
+			terms = append(terms, attribute.Attribute+" eq "+value)
+		}
+		if len(terms) == 1 {
+			return terms[0], true
+		}
+		return "(" + strings.Join(terms, " or ") + ")", true
+	}
+
+	if qual.Operator == quals.QualOperatorLike {
+		if attribute.Type != proto.ColumnType_STRING {
+			return "", false
+		}
+		return scimLikeExpression(attribute.Attribute, qual.Value.GetStringValue())
+	}
+
+	operator, ok := scimComparisonOperators[qual.Operator]
+	if !ok {
+		return "", false
+	}
+	if attribute.Type == proto.ColumnType_BOOL && operator != "eq" && operator != "ne" {
+		return "", false
+	}
+	if slices.Contains(scimCaseInsensitiveAttributes, attribute.Attribute) && operator != "eq" {
+		return "", false
+	}
+	value, ok := scimFilterValue(attribute.Type, qual.Value)
+	if !ok {
+		return "", false
+	}
+	return attribute.Attribute + " " + operator + " " + value, true
+}
+
+// scimLikeExpression translates a LIKE pattern with a leading and/or trailing
+// % wildcard, i.e. 'prefix%' to sw and '%substring%' to co. Patterns with _ or
+// inner wildcards cannot be expressed, nor can suffix matches as Databricks
+// does not support ew.
+func scimLikeExpression(attribute, pattern string) (string, bool) {
+	if strings.ContainsAny(pattern, `_\`) {
+		return "", false
+	}
+	value := strings.TrimSuffix(strings.TrimPrefix(pattern, "%"), "%")
+	if value == "" || strings.Contains(value, "%") {
+		return "", false
+	}
+
+	leading := strings.HasPrefix(pattern, "%")
+	trailing := strings.HasSuffix(pattern, "%")
+	switch {
+	case leading && trailing:
+		return attribute + " co " + scimString(value), true
+	case trailing:
+		return attribute + " sw " + scimString(value), true
+	case leading:
+		return "", false
+	default:
+		return attribute + " eq " + scimString(value), true
+	}
+}
+
+func scimFilterValue(columnType proto.ColumnType, value *proto.QualValue) (string, bool) {
+	switch columnType {
+	case proto.ColumnType_STRING:
+		if _, ok := value.Value.(*proto.QualValue_StringValue); !ok {
+			return "", false
+		}
+		return scimString(value.GetStringValue()), true
+	case proto.ColumnType_BOOL:
+		if _, ok := value.Value.(*proto.QualValue_BoolValue); !ok {
+			return "", false
+		}
+		return strconv.FormatBool(value.GetBoolValue()), true
+	case proto.ColumnType_TIMESTAMP:
+		timestamp := value.GetTimestampValue()
+		if timestamp == nil {
+			return "", false
+		}
+		return scimString(timestamp.AsTime().UTC().Format(time.RFC3339)), true
+	}
+	return "", false
+}
+
+// scimString quotes a SCIM string value as a JSON string.
+func scimString(value string) string {
+	quoted, _ := json.Marshal(value)
+	return string(quoted)
+}
@@ -0,0 +1,89 @@
+package databricks
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/quals"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestBuildSCIMFilter(t *testing.T) {
+	attributes := []scimFilterAttribute{
+		{"user_name", "userName", proto.ColumnType_STRING},
+		{"external_id", "externalId", proto.ColumnType_STRING},
+		{"active", "active", proto.ColumnType_BOOL},
+		{"created", "meta.created", proto.ColumnType_TIMESTAMP},
+	}
+	created := time.Date(2023, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		quals []*proto.Qual
+		want  string
+	}{
+		{"no quals", nil, ""},
+		{"equal", []*proto.Qual{qual("user_name", "=", "bob@example.com")}, `userName eq "bob@example.com"`},
+		{"not equal", []*proto.Qual{qual("external_id", "<>", "Bob")}, `externalId ne "Bob"`},
+		{"case-insensitive not equal", []*proto.Qual{qual("user_name", "<>", "bob@example.com")}, ""},
+		{"like prefix", []*proto.Qual{qual("user_name", "~~", "admin%")}, `userName sw "admin"`},
+		{"like substring", []*proto.Qual{qual("user_name", "~~", "%example%")}, `userName co "example"`},
+		{"like without wildcards", []*proto.Qual{qual("user_name", "~~", "bob@example.com")}, `userName eq "bob@example.com"`},
+		{"like suffix", []*proto.Qual{qual("user_name", "~~", "%.com")}, ""},
+		{"like single character wildcard", []*proto.Qual{qual("user_name", "~~", "b_b%")}, ""},
+		{"like inner wildcard", []*proto.Qual{qual("user_name", "~~", "b%b%")}, ""},
+		{"comparison", []*proto.Qual{qual("external_id", ">=", "b"), qual("external_id", "<", "d")}, `externalId ge "b" and externalId lt "d"`},
+		{"case-insensitive comparison", []*proto.Qual{qual("user_name", ">=", "b"), qual("user_name", "<", "d")}, ""},
+		{"in list", []*proto.Qual{qual("user_name", "=", []string{"a@example.com", "b@example.com"})}, `(userName eq "a@example.com" or userName eq "b@example.com")`},
+		{"present", []*proto.Qual{{FieldName: "user_name", Operator: &proto.Qual_StringValue{StringValue: "is not null"}}}, "userName pr"},
+		{"boolean", []*proto.Qual{qual("active", "=", true)}, "active eq true"},
+		{"boolean not equal", []*proto.Qual{qual("active", "<>", true)}, "active ne true"},
+		{"boolean comparison", []*proto.Qual{qual("active", ">", false)}, ""},
+		{"timestamp", []*proto.Qual{{
+			FieldName: "created",
+			Operator:  &proto.Qual_StringValue{StringValue: ">"},
+			Value:     &proto.QualValue{Value: &proto.QualValue_TimestampValue{TimestampValue: timestamppb.New(created)}},
+		}}, `meta.created gt "2023-07-01T12:00:00Z"`},
+		{"quoted value", []*proto.Qual{qual("user_name", "=", `say "hi"`)}, `userName eq "say \"hi\""`},
+		{"control character", []*proto.Qual{qual("user_name", "=", "a\tb\x00")}, `userName eq "a\tb\u0000"`},
+		{"combined", []*proto.Qual{qual("user_name", "~~", "a%"), qual("active", "=", false)}, `userName sw "a" and active eq false`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyColumnQuals := plugin.KeyColumnQualMap{}
+			for _, q := range tt.quals {
+				if keyColumnQuals[q.FieldName] == nil {
+					keyColumnQuals[q.FieldName] = &plugin.KeyColumnQuals{Name: q.FieldName}
+				}
+				keyColumnQuals[q.FieldName].Quals = append(keyColumnQuals[q.FieldName].Quals, quals.NewQual(q))
+			}
+
+			if got := buildSCIMFilter(attributes, keyColumnQuals); got != tt.want {
+				t.Errorf("buildSCIMFilter() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSCIMKeyColumnOperators(t *testing.T) {
+	keyColumns := scimKeyColumns(scimUserFilterAttributes)
+	operators := map[string][]string{}
+	for _, keyColumn := range keyColumns {
+		operators[keyColumn.Name] = keyColumn.Operators
+	}
+
+	// Case-insensitive attributes only push down the operators which match a
+	// superset of the rows
+	for _, column := range []string{"user_name", "display_name"} {
+		if got, want := operators[column], []string{"=", "~~", "is not null"}; !slices.Equal(got, want) {
+			t.Errorf("%s operators = %v, want %v", column, got, want)
+		}
+	}
+	if got, want := operators["external_id"], []string{"=", "<>", ">", ">=", "<", "<=", "~~", "is not null"}; !slices.Equal(got, want) {
+		t.Errorf("external_id operators = %v, want %v", got, want)
+	}
+}
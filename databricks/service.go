@@ -3,9 +3,11 @@ package databricks
 import (
 	"context"
 	"errors"
-	"os"
+	"fmt"
 
 	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/client"
+	"github.com/turbot/steampipe-plugin-sdk/v5/memoize"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 )
 
@@ -22,60 +24,14 @@ func getAccountClient(ctx context.Context, d *plugin.QueryData) (*databricks.Acc
 var getAccountClientCached = plugin.HydrateFunc(getAccountClientUncached).Memoize()
 
 func getAccountClientUncached(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-
-	databricksConfig := GetConfig(d.Connection)
-	config := &databricks.Config{}
-
-	// Account ID is required for a common column
-	if databricksConfig.AccountId != nil {
-		config.AccountID = *databricksConfig.AccountId
-	}
-
-	if config.AccountID == "" && os.Getenv("DATABRICKS_ACCOUNT_ID") == "" {
-		return nil, errors.New("account_id must be configured")
-	}
-
-	// Check for profile and config file first
-	if databricksConfig.Profile != nil {
-		config.Profile = *databricksConfig.Profile
-	}
-
-	if databricksConfig.ConfigFilePath != nil {
-		config.ConfigFile = *databricksConfig.ConfigFilePath
-	}
-
-	// If not using a profile and config file, check for OAuth config or token
-	if config.ConfigFile == "" && os.Getenv("DATABRICKS_CONFIG_PROFILE") == "" {
-
-		// Account host is required but can be set in the profile config
-		if databricksConfig.AccountHost != nil {
-			config.Host = *databricksConfig.AccountHost
-		}
-
-		if databricksConfig.ClientID != nil && databricksConfig.ClientSecret != nil {
-			config.ClientID = *databricksConfig.ClientID
-			config.ClientSecret = *databricksConfig.ClientSecret
-		}
-
-		empty_oauth_config := config.ClientID == "" && config.ClientSecret == "" && os.Getenv("DATABRICKS_CLIENT_ID") == "" && os.Getenv("DATABRICKS_CLIENT_SECRET") == ""
-
-                // If not using OAuth config, check for token
-		if empty_oauth_config && databricksConfig.AccountToken != nil {
-			config.Token = *databricksConfig.AccountToken
-		}
-
-		// Finally, check for a username and password
-		if empty_oauth_config && config.Token == "" && os.Getenv("DATABRICKS_TOKEN") == "" {
-			if databricksConfig.Username != nil {
-				config.Username = *databricksConfig.Username
-			}
-			if databricksConfig.Password != nil {
-				config.Password = *databricksConfig.Password
-			}
-		}
+	creds, err := resolveCredentials(GetConfig(d.Connection), clientScopeAccount, "")
+	if err != nil {
+		plugin.Logger(ctx).Error("Unable to resolve account credentials:", err.Error())
+		return nil, err
 	}
+	plugin.Logger(ctx).Debug("getAccountClient", "auth_type", creds.AuthType, "auth_type_source", creds.AuthTypeSource)
 
-	client, err := databricks.NewAccountClient(config)
+	client, err := databricks.NewAccountClient((*databricks.Config)(creds.Config))
 	if err != nil {
 		plugin.Logger(ctx).Error("Unable to initialize account client:", err.Error())
 		return nil, err
@@ -85,6 +41,20 @@ func getAccountClientUncached(ctx context.Context, d *plugin.QueryData, h *plugi
 }
 
 func getWorkspaceClient(ctx context.Context, d *plugin.QueryData) (*databricks.WorkspaceClient, error) {
+	// The workspaces to aggregate could not be listed
+	if msg := d.EqualsQualString(matrixKeyWorkspaceError); msg != "" {
+		return nil, errors.New(msg)
+	}
+
+	// When workspaces are aggregated, use the client for the workspace of the current matrix item
+	if host := d.EqualsQualString(matrixKeyWorkspaceHost); host != "" {
+		i, err := getWorkspaceClientForHostCached(ctx, d, nil)
+		if err != nil {
+			return nil, err
+		}
+		return i.(*databricks.WorkspaceClient), nil
+	}
+
 	i, err := getWorkspacetClientCached(ctx, d, nil)
 	if err != nil {
 		return nil, err
@@ -97,59 +67,68 @@ func getWorkspaceClient(ctx context.Context, d *plugin.QueryData) (*databricks.W
 var getWorkspacetClientCached = plugin.HydrateFunc(getWorkspacetClientUncached).Memoize()
 
 func getWorkspacetClientUncached(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	databricksConfig := GetConfig(d.Connection)
-	config := &databricks.Config{}
+	return newWorkspaceClient(ctx, d, "")
+}
 
-	// Account ID is required for a common column
-	if databricksConfig.AccountId != nil {
-		config.AccountID = *databricksConfig.AccountId
-	}
+// Cached form of getWorkspaceClientForHost, keyed on the workspace host of
+// the current matrix item.
+var getWorkspaceClientForHostCached = plugin.HydrateFunc(getWorkspaceClientForHostUncached).Memoize(memoize.WithCacheKeyFunction(getWorkspaceClientForHostCacheKey))
 
-	if config.AccountID == "" && os.Getenv("DATABRICKS_ACCOUNT_ID") == "" {
-		return nil, errors.New("account_id must be configured")
-	}
+// Build a cache key for the call to getWorkspaceClientForHost.
+func getWorkspaceClientForHostCacheKey(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	key := fmt.Sprintf("getWorkspaceClientForHost-%s", d.EqualsQualString(matrixKeyWorkspaceHost))
+	return key, nil
+}
 
-	// Check for profile and config file first
-	if databricksConfig.Profile != nil {
-		config.Profile = *databricksConfig.Profile
-	}
+func getWorkspaceClientForHostUncached(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// The workspace credentials are reused, only the host differs per workspace
+	return newWorkspaceClient(ctx, d, d.EqualsQualString(matrixKeyWorkspaceHost))
+}
 
-	if databricksConfig.ConfigFilePath != nil {
-		config.ConfigFile = *databricksConfig.ConfigFilePath
+// getWorkspaceAPIClient returns a client for calling workspace REST endpoints
+// directly, for APIs which are not covered by the SDK or which the SDK does not
+// encode correctly. It shares the credentials of the workspace client.
+func getWorkspaceAPIClient(ctx context.Context, d *plugin.QueryData) (*client.DatabricksClient, error) {
+	i, err := getWorkspaceAPIClientCached(ctx, d, nil)
+	if err != nil {
+		return nil, err
 	}
+	return i.(*client.DatabricksClient), nil
+}
 
-        // If not using a profile and config file, check for OAuth config or token
-	if config.ConfigFile == "" && os.Getenv("DATABRICKS_CONFIG_PROFILE") == "" {
+// Cached form of getWorkspaceAPIClient, keyed on the workspace host of the
+// current matrix item.
+var getWorkspaceAPIClientCached = plugin.HydrateFunc(getWorkspaceAPIClientUncached).Memoize(memoize.WithCacheKeyFunction(getWorkspaceAPIClientCacheKey))
 
-		// Workspace host is required but can be set in the profile config
-		if databricksConfig.WorkspaceHost != nil {
-			config.Host = *databricksConfig.WorkspaceHost
-		}
+func getWorkspaceAPIClientCacheKey(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	key := fmt.Sprintf("getWorkspaceAPIClient-%s", d.EqualsQualString(matrixKeyWorkspaceHost))
+	return key, nil
+}
 
-		if databricksConfig.ClientID != nil && databricksConfig.ClientSecret != nil {
-			config.ClientID = *databricksConfig.ClientID
-			config.ClientSecret = *databricksConfig.ClientSecret
-		}
+func getWorkspaceAPIClientUncached(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	workspaceClient, err := getWorkspaceClient(ctx, d)
+	if err != nil {
+		return nil, err
+	}
 
-		empty_oauth_config := config.ClientID == "" && config.ClientSecret == "" && os.Getenv("DATABRICKS_CLIENT_ID") == "" && os.Getenv("DATABRICKS_CLIENT_SECRET") == ""
+	apiClient, err := client.New(workspaceClient.Config)
+	if err != nil {
+		plugin.Logger(ctx).Error("Unable to initialize workspace API client:", err.Error())
+		return nil, err
+	}
 
-                // If not using OAuth config, check for token
-		if empty_oauth_config && databricksConfig.WorkspaceToken != nil {
-			config.Token = *databricksConfig.WorkspaceToken
-		}
+	return apiClient, nil
+}
 
-		// Finally, check for a username and password
-		if empty_oauth_config && config.Token == "" && os.Getenv("DATABRICKS_TOKEN") == "" {
-			if databricksConfig.Username != nil {
-				config.Username = *databricksConfig.Username
-			}
-			if databricksConfig.Password != nil {
-				config.Password = *databricksConfig.Password
-			}
-		}
+func newWorkspaceClient(ctx context.Context, d *plugin.QueryData, host string) (*databricks.WorkspaceClient, error) {
+	creds, err := resolveCredentials(GetConfig(d.Connection), clientScopeWorkspace, host)
+	if err != nil {
+		plugin.Logger(ctx).Error("Unable to resolve workspace credentials:", err.Error())
+		return nil, err
 	}
+	plugin.Logger(ctx).Debug("getWorkspaceClient", "host", creds.Config.Host, "auth_type", creds.AuthType, "auth_type_source", creds.AuthTypeSource)
 
-	client, err := databricks.NewWorkspaceClient(config)
+	client, err := databricks.NewWorkspaceClient((*databricks.Config)(creds.Config))
 	if err != nil {
 		plugin.Logger(ctx).Error("Unable to initialize workspace client:", err.Error())
 		return nil, err
@@ -17,13 +17,48 @@ func tableDatabricksCatalog(_ context.Context) *plugin.Table {
 		Description: "Gets an array of catalogs in the metastore.",
 		List: &plugin.ListConfig{
 			Hydrate: listCatalogs,
+			Tags:    map[string]string{"service": "unity_catalog"},
 		},
 		Get: &plugin.GetConfig{
-			KeyColumns:        plugin.SingleColumn("name"),
-			ShouldIgnoreError: isNotFoundError([]string{"CATALOG_DOES_NOT_EXIST"}),
-			Hydrate:           getCatalog,
+			KeyColumns: plugin.SingleColumn("name"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"CATALOG_DOES_NOT_EXIST"}),
+			},
+			Hydrate: getCatalog,
+			Tags:    map[string]string{"service": "unity_catalog"},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getCatalogPermissions,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "unity_catalog"},
+			},
+			{
+				Func: getCatalogEffectivePermissions,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "unity_catalog"},
+			},
+			{
+				Func: getCatalogWorkspaceBindings,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "unity_catalog"},
+			},
+			{
+				Func: getCatalogTags,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "unity_catalog"},
+			},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "name",
 				Description: "Name of the catalog.",
@@ -144,6 +179,13 @@ func tableDatabricksCatalog(_ context.Context) *plugin.Table {
 				Hydrate:     getCatalogWorkspaceBindings,
 				Transform:   transform.FromValue(),
 			},
+			{
+				Name:        "tags",
+				Description: "The tags assigned to the catalog.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getCatalogTags,
+				Transform:   transform.FromValue(),
+			},
 
 			// Standard Steampipe columns
 			{
@@ -270,3 +312,22 @@ func getCatalogEffectivePermissions(ctx context.Context, d *plugin.QueryData, h
 	}
 	return permission.PrivilegeAssignments, nil
 }
+
+func getCatalogTags(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	name := h.Item.(catalog.CatalogInfo).Name
+
+	// Create client
+	client, err := getWorkspaceAPIClient(ctx, d)
+	if err != nil {
+		logger.Error("databricks_catalog.getCatalogTags", "connection_error", err)
+		return nil, err
+	}
+
+	assignments, err := listCatalogTagAssignments(ctx, client, "catalogs", name)
+	if err != nil {
+		logger.Error("databricks_catalog.getCatalogTags", "api_error", err)
+		return nil, err
+	}
+	return catalogTagsMap(assignments), nil
+}
@@ -0,0 +1,190 @@
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableDatabricksCatalogColumnLineage(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "databricks_catalog_column_lineage",
+		Description: "Get the upstream and downstream columns of a table column.",
+		List: &plugin.ListConfig{
+			Hydrate: listCatalogColumnLineage,
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"TABLE_DOES_NOT_EXIST", "CATALOG_DOES_NOT_EXIST", "SCHEMA_DOES_NOT_EXIST"}),
+			},
+			KeyColumns: plugin.AllColumns([]string{"table_full_name", "column_name"}),
+			Tags:       map[string]string{"service": "unity_catalog"},
+		},
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
+			{
+				Name:        "table_full_name",
+				Description: "Full name of the table of the column whose lineage is returned, in form of __catalog_name__.__schema_name__.__table_name__.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "column_name",
+				Description: "The name of the column whose lineage is returned.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "direction",
+				Description: "Whether the entity is upstream of the column, i.e. the column is derived from it, or downstream of it, i.e. it is derived from the column.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "entity_type",
+				Description: "The type of the entity, always column.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "entity_id",
+				Description: "The full name of the column, in form of __catalog_name__.__schema_name__.__table_name__.__column_name__.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "entity_name",
+				Description: "The name of the column.",
+				Transform:   transform.FromField("Column.Name"),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "entity_table_full_name",
+				Description: "Full name of the table of the column, in form of __catalog_name__.__schema_name__.__table_name__.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "entity_catalog_name",
+				Description: "The name of the catalog of the column.",
+				Transform:   transform.FromField("Column.CatalogName"),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "entity_schema_name",
+				Description: "The name of the schema of the column.",
+				Transform:   transform.FromField("Column.SchemaName"),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "entity_table_name",
+				Description: "The name of the table of the column, relative to its schema.",
+				Transform:   transform.FromField("Column.TableName"),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "entity_table_type",
+				Description: "The type of the table of the column, e.g. TABLE, VIEW or PATH.",
+				Transform:   transform.FromField("Column.TableType").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "lineage_timestamp",
+				Description: "The time the lineage between the columns was last observed.",
+				Transform:   transform.FromField("Column.LineageTimestamp").Transform(lineageTimestampToTime),
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+
+			// Standard Steampipe columns
+			{
+				Name:        "title",
+				Description: "The title of the resource.",
+				Transform:   transform.FromField("EntityId"),
+				Type:        proto.ColumnType_STRING,
+			},
+		}),
+	}
+}
+
+// columnLineageResponse is the response of the column lineage API.
+type columnLineageResponse struct {
+	UpstreamCols   []lineageColumnInfo `json:"upstream_cols"`
+	DownstreamCols []lineageColumnInfo `json:"downstream_cols"`
+}
+
+type lineageColumnInfo struct {
+	Name             string `json:"name"`
+	CatalogName      string `json:"catalog_name"`
+	SchemaName       string `json:"schema_name"`
+	TableName        string `json:"table_name"`
+	TableType        string `json:"table_type,omitempty"`
+	LineageTimestamp string `json:"lineage_timestamp,omitempty"`
+}
+
+// catalogColumnLineageInfo is a column upstream or downstream of a column.
+type catalogColumnLineageInfo struct {
+	TableFullName       string
+	ColumnName          string
+	Direction           string
+	EntityType          string
+	EntityId            string
+	EntityTableFullName string
+	Column              lineageColumnInfo
+}
+
+//// LIST FUNCTION
+
+func listCatalogColumnLineage(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	tableFullName := d.EqualsQualString("table_full_name")
+	columnName := d.EqualsQualString("column_name")
+
+	// Create client
+	client, err := getWorkspaceAPIClient(ctx, d)
+	if err != nil {
+		logger.Error("databricks_catalog_column_lineage.listCatalogColumnLineage", "connection_error", err)
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("table_name", tableFullName)
+	params.Set("column_name", columnName)
+
+	var response columnLineageResponse
+	err = client.Do(ctx, http.MethodGet, "/api/2.0/lineage-tracking/column-lineage?"+params.Encode(), nil, &response)
+	if err != nil {
+		logger.Error("databricks_catalog_column_lineage.listCatalogColumnLineage", "api_error", err)
+		return nil, err
+	}
+
+	var items []catalogColumnLineageInfo
+	for _, column := range response.UpstreamCols {
+		items = append(items, newCatalogColumnLineageInfo(tableFullName, columnName, "upstream", column))
+	}
+	for _, column := range response.DownstreamCols {
+		items = append(items, newCatalogColumnLineageInfo(tableFullName, columnName, "downstream", column))
+	}
+
+	for _, item := range items {
+		d.StreamListItem(ctx, item)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func newCatalogColumnLineageInfo(tableFullName, columnName, direction string, column lineageColumnInfo) catalogColumnLineageInfo {
+	entityTableFullName := fmt.Sprintf("%s.%s.%s", column.CatalogName, column.SchemaName, column.TableName)
+	return catalogColumnLineageInfo{
+		TableFullName:       tableFullName,
+		ColumnName:          columnName,
+		Direction:           direction,
+		EntityType:          "column",
+		EntityId:            entityTableFullName + "." + column.Name,
+		EntityTableFullName: entityTableFullName,
+		Column:              column,
+	}
+}
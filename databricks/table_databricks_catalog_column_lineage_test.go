@@ -0,0 +1,38 @@
+package databricks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestListCatalogColumnLineage(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_catalog_column_lineage", []string{"table_full_name", "column_name", "direction", "entity_type", "entity_id", "entity_name", "entity_table_full_name", "entity_table_type"}, []*proto.Qual{
+		qual("table_full_name", "=", "main.sales.orders"),
+		qual("column_name", "=", "customer_email"),
+	}, 0)
+
+	if got, want := columnStrings(rows, "entity_id"), []string{"main.bronze.raw_customers.email", "main.gold.customers_masked.email_hash", "main.gold.daily_revenue.customer_email"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("entity_id = %v, want %v", got, want)
+	}
+
+	upstream := rowWith(t, rows, "entity_id", "main.bronze.raw_customers.email")
+	if upstream["direction"] != "upstream" || upstream["entity_type"] != "column" || upstream["entity_name"] != "email" || upstream["entity_table_full_name"] != "main.bronze.raw_customers" {
+		t.Errorf("upstream = %v, want the email column of raw_customers", upstream)
+	}
+	if got := rowWith(t, rows, "entity_id", "main.gold.customers_masked.email_hash")["direction"]; got != "downstream" {
+		t.Errorf("direction = %v, want downstream", got)
+	}
+	if row := rowWith(t, rows, "entity_id", "main.gold.daily_revenue.customer_email"); row["table_full_name"] != "main.sales.orders" || row["column_name"] != "customer_email" {
+		t.Errorf("row = %v, want the lineage of main.sales.orders.customer_email", row)
+	}
+
+	requests := fake.requestsTo("/api/2.0/lineage-tracking/column-lineage")
+	if len(requests) != 1 || requests[0].Query.Get("table_name") != "main.sales.orders" || requests[0].Query.Get("column_name") != "customer_email" {
+		t.Errorf("requests = %v, want a single request for main.sales.orders.customer_email", requests)
+	}
+}
@@ -16,13 +16,18 @@ func tableDatabricksCatalogConnection(_ context.Context) *plugin.Table {
 		Description: "Gets an array of connections for the workspace.",
 		List: &plugin.ListConfig{
 			Hydrate: listCatalogConnections,
+			Tags:    map[string]string{"service": "unity_catalog"},
 		},
 		Get: &plugin.GetConfig{
-			KeyColumns:        plugin.SingleColumn("name"),
-			ShouldIgnoreError: isNotFoundError([]string{"CONNECTION_DOES_NOT_EXIST"}),
-			Hydrate:           getCatalogConnection,
+			KeyColumns: plugin.SingleColumn("name"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"CONNECTION_DOES_NOT_EXIST"}),
+			},
+			Hydrate: getCatalogConnection,
+			Tags:    map[string]string{"service": "unity_catalog"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "name",
 				Description: "Name of the connection.",
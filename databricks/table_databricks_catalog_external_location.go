@@ -17,13 +17,34 @@ func tableDatabricksCatalogExternalLocation(_ context.Context) *plugin.Table {
 		Description: "Gets an array of external locations from the metastore.",
 		List: &plugin.ListConfig{
 			Hydrate: listCatalogExternalLocations,
+			Tags:    map[string]string{"service": "unity_catalog"},
 		},
 		Get: &plugin.GetConfig{
-			KeyColumns:        plugin.SingleColumn("name"),
-			ShouldIgnoreError: isNotFoundError([]string{"EXTERNAL_LOCATION_DOES_NOT_EXIST"}),
-			Hydrate:           getCatalogExternalLocation,
+			KeyColumns: plugin.SingleColumn("name"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"EXTERNAL_LOCATION_DOES_NOT_EXIST"}),
+			},
+			Hydrate: getCatalogExternalLocation,
+			Tags:    map[string]string{"service": "unity_catalog"},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getCatalogExternalLocationPermissions,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "unity_catalog"},
+			},
+			{
+				Func: getCatalogExternalLocationEffectivePermissions,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "unity_catalog"},
+			},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "name",
 				Description: "Human readable name that identifies the experiment.",
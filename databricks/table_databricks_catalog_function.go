@@ -16,16 +16,38 @@ func tableDatabricksCatalogFunction(_ context.Context) *plugin.Table {
 		Name:        "databricks_catalog_function",
 		Description: "List functions within the specified parent catalog and schema.",
 		List: &plugin.ListConfig{
-			Hydrate:           listCatalogFunctions,
-			ShouldIgnoreError: isNotFoundError([]string{"CATALOG_DOES_NOT_EXIST", "SCHEMA_DOES_NOT_EXIST"}),
-			KeyColumns:        plugin.AllColumns([]string{"catalog_name", "schema_name"}),
+			ParentHydrate: listCatalogWalkSchemas,
+			Hydrate:       listCatalogFunctions,
+			KeyColumns:    plugin.OptionalColumns([]string{"catalog_name", "schema_name"}),
+			ParentTags:    map[string]string{"service": "unity_catalog"},
+			Tags:          map[string]string{"service": "unity_catalog"},
 		},
 		Get: &plugin.GetConfig{
-			KeyColumns:        plugin.SingleColumn("full_name"),
-			ShouldIgnoreError: isNotFoundError([]string{"FUNCTION_DOES_NOT_EXIST", "CATALOG_DOES_NOT_EXIST", "SCHEMA_DOES_NOT_EXIST"}),
-			Hydrate:           getCatalogFunction,
+			KeyColumns: plugin.SingleColumn("full_name"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"FUNCTION_DOES_NOT_EXIST", "CATALOG_DOES_NOT_EXIST", "SCHEMA_DOES_NOT_EXIST"}),
+			},
+			Hydrate: getCatalogFunction,
+			Tags:    map[string]string{"service": "unity_catalog"},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getCatalogFunctionPermissions,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "unity_catalog"},
+			},
+			{
+				Func: getCatalogFunctionEffectivePermissions,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "unity_catalog"},
+			},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "function_id",
 				Description: "Id of Function, relative to parent schema.",
@@ -205,14 +227,19 @@ func tableDatabricksCatalogFunction(_ context.Context) *plugin.Table {
 
 func listCatalogFunctions(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	logger := plugin.Logger(ctx)
+	parent := h.Item.(catalogWalkItem[catalog.SchemaInfo])
+	schema := parent.Value
 
-	if d.EqualsQualString("catalog_name") == "" || d.EqualsQualString("schema_name") == "" {
-		return nil, nil
+	// Bound the number of schemas listed at the same time when walking the metastore
+	done, err := parent.wait(ctx)
+	if err != nil {
+		return nil, err
 	}
+	defer done()
 
 	request := catalog.ListFunctionsRequest{
-		SchemaName:  d.EqualsQualString("schema_name"),
-		CatalogName: d.EqualsQualString("catalog_name"),
+		SchemaName:  schema.Name,
+		CatalogName: schema.CatalogName,
 	}
 
 	// Create client
@@ -224,6 +251,9 @@ func listCatalogFunctions(ctx context.Context, d *plugin.QueryData, h *plugin.Hy
 
 	response, err := client.Functions.ListAll(ctx, request)
 	if err != nil {
+		if shouldIgnoreCatalogWalkError(ctx, d, h, err) {
+			return nil, nil
+		}
 		logger.Error("databricks_catalog_function.listCatalogFunctions", "api_error", err)
 		return nil, err
 	}
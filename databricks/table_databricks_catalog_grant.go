@@ -0,0 +1,496 @@
+package databricks
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableDatabricksCatalogGrant(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "databricks_catalog_grant",
+		Description: "List the privileges granted on Unity Catalog securables, one per securable, principal and privilege.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listCatalogGrantSecurables,
+			Hydrate:       listCatalogGrants,
+			KeyColumns:    plugin.OptionalColumns([]string{"securable_type", "full_name", "principal"}),
+			ParentTags:    map[string]string{"service": "unity_catalog"},
+			Tags:          map[string]string{"service": "unity_catalog"},
+		},
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
+			{
+				Name:        "securable_type",
+				Description: "The type of the securable, one of metastore, catalog, schema, table, view, volume, function, external_location, storage_credential, connection or share.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "full_name",
+				Description: "The full name of the securable, e.g. __catalog_name__.__schema_name__.__table_name__ for a table, or the ID of the metastore.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "principal",
+				Description: "The user, group or service principal the privilege is granted to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "privilege",
+				Description: "The privilege granted, e.g. USE_CATALOG, SELECT or MODIFY.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "is_effective",
+				Description: "False for a privilege granted directly on the securable, true for a privilege the principal holds on it, either directly or inherited from a parent securable.",
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "inherited_from_type",
+				Description: "The type of the securable an effective privilege is inherited from.",
+				Transform:   transform.FromField("InheritedFromType").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "inherited_from_name",
+				Description: "The full name of the securable an effective privilege is inherited from.",
+				Transform:   transform.FromField("InheritedFromName").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// Standard Steampipe columns
+			{
+				Name:        "title",
+				Description: "The title of the resource.",
+				Transform:   transform.FromField("FullName"),
+				Type:        proto.ColumnType_STRING,
+			},
+		}),
+	}
+}
+
+// catalogGrantSecurableTypes are the securable types grants are listed for.
+// Views are securables of type table in the grants API.
+var catalogGrantSecurableTypes = []string{"metastore", "catalog", "schema", "table", "view", "volume", "function", "external_location", "storage_credential", "connection", "share"}
+
+// catalogSecurable is a securable grants or tags are listed for. The columns
+// of tables and views are kept when they are walked.
+type catalogSecurable struct {
+	Type     string
+	FullName string
+	Columns  []string
+}
+
+// catalogGrantEffectiveColumns are the columns describing effective
+// privileges, which are only listed when one of them is requested.
+var catalogGrantEffectiveColumns = []string{"is_effective", "inherited_from_type", "inherited_from_name"}
+
+// catalogGrantInfo is a privilege of a principal on a securable.
+type catalogGrantInfo struct {
+	SecurableType     string
+	FullName          string
+	Principal         string
+	Privilege         string
+	IsEffective       bool
+	InheritedFromType string
+	InheritedFromName string
+}
+
+//// LIST FUNCTION
+
+// listCatalogGrantSecurables is the parent hydrate streaming the securables
+// whose grants are listed as catalogWalkItem[catalogSecurable], and the
+// schemas whose tables, views, volumes and functions are walked as
+// catalogWalkItem[catalog.SchemaInfo]. A full name narrows the walk to the
+// securables it could name: the metastore, catalogs and workspace level
+// securables for a name without dots, a schema for a name with one, and the
+// securables of a single schema for a name with two.
+func listCatalogGrantSecurables(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	wants := catalogGrantWants(d)
+	fullName := d.EqualsQualString("full_name")
+
+	slots := newCatalogWalkSlots(d)
+	stream := func(securableType, name string) bool {
+		d.StreamListItem(ctx, catalogWalkItem[catalogSecurable]{Value: catalogSecurable{Type: securableType, FullName: name}, slots: slots})
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		return d.RowsRemaining(ctx) != 0
+	}
+
+	var names []string
+	if fullName != "" {
+		names = strings.Split(fullName, ".")
+	}
+	switch len(names) {
+	case 2:
+		if wants("schema") {
+			stream("schema", fullName)
+		}
+		return nil, nil
+	case 3:
+		if wantsCatalogSchemaSecurables(wants) {
+			d.StreamListItem(ctx, catalogWalkItem[catalog.SchemaInfo]{Value: catalog.SchemaInfo{CatalogName: names[0], Name: names[1], FullName: names[0] + "." + names[1]}, slots: slots})
+		}
+		return nil, nil
+	case 0, 1:
+	default:
+		return nil, nil
+	}
+
+	// Create client
+	client, err := getWorkspaceClient(ctx, d)
+	if err != nil {
+		logger.Error("databricks_catalog_grant.listCatalogGrantSecurables", "connection_error", err)
+		return nil, err
+	}
+
+	ignoreError := func(err error) bool {
+		return shouldIgnoreErrors(catalogWalkIgnoreErrors)(ctx, d, h, err)
+	}
+
+	// Securables at the metastore level
+	if wants("metastore") {
+		assignment, err := client.Metastores.Current(ctx)
+		if err != nil {
+			if !ignoreError(err) {
+				logger.Error("databricks_catalog_grant.listCatalogGrantSecurables", "api_error", err)
+				return nil, err
+			}
+		} else if fullName == "" || fullName == assignment.MetastoreId {
+			if !stream("metastore", assignment.MetastoreId) {
+				return nil, nil
+			}
+		}
+	}
+
+	if fullName != "" {
+		// The name is checked by the grants API, so nothing needs to be listed
+		for _, securableType := range []string{"catalog", "external_location", "storage_credential", "connection", "share"} {
+			if wants(securableType) && !stream(securableType, fullName) {
+				return nil, nil
+			}
+		}
+		return nil, nil
+	}
+
+	wantsSchemas := wants("schema") || wantsCatalogSchemaSecurables(wants)
+	if wants("catalog") || wantsSchemas {
+		catalogs, err := client.Catalogs.ListAll(ctx)
+		if err != nil && !ignoreError(err) {
+			logger.Error("databricks_catalog_grant.listCatalogGrantSecurables", "api_error", err)
+			return nil, err
+		}
+		var catalogNames []string
+		for _, item := range catalogs {
+			if wants("catalog") && !stream("catalog", item.Name) {
+				return nil, nil
+			}
+			catalogNames = append(catalogNames, item.Name)
+		}
+		if wantsSchemas {
+			if err := streamCatalogWalkSchemas(ctx, d, h, client, catalogNames, "", slots); err != nil {
+				logger.Error("databricks_catalog_grant.listCatalogGrantSecurables", "api_error", err)
+				return nil, err
+			}
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	if wants("external_location") {
+		locations, err := client.ExternalLocations.ListAll(ctx)
+		if err != nil && !ignoreError(err) {
+			logger.Error("databricks_catalog_grant.listCatalogGrantSecurables", "api_error", err)
+			return nil, err
+		}
+		for _, item := range locations {
+			if !stream("external_location", item.Name) {
+				return nil, nil
+			}
+		}
+	}
+
+	if wants("storage_credential") {
+		credentials, err := client.StorageCredentials.ListAll(ctx)
+		if err != nil && !ignoreError(err) {
+			logger.Error("databricks_catalog_grant.listCatalogGrantSecurables", "api_error", err)
+			return nil, err
+		}
+		for _, item := range credentials {
+			if !stream("storage_credential", item.Name) {
+				return nil, nil
+			}
+		}
+	}
+
+	if wants("connection") {
+		connections, err := client.Connections.ListAll(ctx)
+		if err != nil && !ignoreError(err) {
+			logger.Error("databricks_catalog_grant.listCatalogGrantSecurables", "api_error", err)
+			return nil, err
+		}
+		for _, item := range connections {
+			if !stream("connection", item.Name) {
+				return nil, nil
+			}
+		}
+	}
+
+	if wants("share") {
+		shares, err := client.Shares.ListAll(ctx)
+		if err != nil && !ignoreError(err) {
+			logger.Error("databricks_catalog_grant.listCatalogGrantSecurables", "api_error", err)
+			return nil, err
+		}
+		for _, item := range shares {
+			if !stream("share", item.Name) {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func listCatalogGrants(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+
+	// Create client
+	client, err := getWorkspaceClient(ctx, d)
+	if err != nil {
+		logger.Error("databricks_catalog_grant.listCatalogGrants", "connection_error", err)
+		return nil, err
+	}
+
+	parent, ok := h.Item.(catalogWalkParent)
+	if !ok {
+		return nil, nil
+	}
+
+	// Bound the number of securables and schemas listed at the same time
+	done, err := parent.wait(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	switch parent := h.Item.(type) {
+	case catalogWalkItem[catalogSecurable]:
+		_, err = streamCatalogSecurableGrants(ctx, d, h, client, parent.Value)
+	case catalogWalkItem[catalog.SchemaInfo]:
+		err = streamCatalogSchemaGrants(ctx, d, h, client, parent.Value)
+	}
+	if err != nil {
+		logger.Error("databricks_catalog_grant.listCatalogGrants", "api_error", err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+// catalogGrantWants returns whether the grants of a securable type are listed,
+// given the securable_type quals.
+func catalogGrantWants(d *plugin.QueryData) func(string) bool {
+	types := map[string]bool{}
+	for _, value := range qualValues(d.Quals["securable_type"]) {
+		types[value.GetStringValue()] = true
+	}
+	return func(securableType string) bool {
+		return len(types) == 0 || types[securableType]
+	}
+}
+
+// wantsCatalogSchemaSecurables reports whether the securables within schemas
+// are listed.
+func wantsCatalogSchemaSecurables(wants func(string) bool) bool {
+	return wants("table") || wants("view") || wants("volume") || wants("function")
+}
+
+// streamCatalogSchemaGrants streams the grants of the tables, views, volumes
+// and functions of a schema, or only of those named by a full_name qual with
+// two dots. When walking the metastore, the grants of the schema itself come
+// first. The grants of the schema's securables are read one at a time, the
+// schemas being walked at the same time are bounded by the walk slots. Listing
+// errors for securables dropped or not readable while walking are skipped.
+func streamCatalogSchemaGrants(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, client *databricks.WorkspaceClient, schema catalog.SchemaInfo) error {
+	wants := catalogGrantWants(d)
+	ignoreError := func(err error) bool {
+		return shouldIgnoreErrors(catalogWalkIgnoreErrors)(ctx, d, h, err)
+	}
+
+	name := ""
+	if names := strings.Split(d.EqualsQualString("full_name"), "."); len(names) == 3 {
+		name = names[2]
+	} else if wants("schema") {
+		if ok, err := streamCatalogSecurableGrants(ctx, d, h, client, catalogSecurable{Type: "schema", FullName: schema.FullName}); !ok {
+			return err
+		}
+	}
+	matches := func(itemName string) bool {
+		return name == "" || itemName == name
+	}
+
+	if wants("table") || wants("view") {
+		tables, err := client.Tables.ListAll(ctx, catalog.ListTablesRequest{CatalogName: schema.CatalogName, SchemaName: schema.Name})
+		if err != nil && !ignoreError(err) {
+			return err
+		}
+		for _, item := range tables {
+			securableType := "table"
+			if item.TableType == catalog.TableTypeView || item.TableType == catalog.TableTypeMaterializedView {
+				securableType = "view"
+			}
+			if !wants(securableType) || !matches(item.Name) {
+				continue
+			}
+			if ok, err := streamCatalogSecurableGrants(ctx, d, h, client, catalogSecurable{Type: securableType, FullName: item.FullName}); !ok {
+				return err
+			}
+		}
+	}
+
+	if wants("volume") {
+		volumes, err := client.Volumes.ListAll(ctx, catalog.ListVolumesRequest{CatalogName: schema.CatalogName, SchemaName: schema.Name})
+		if err != nil && !ignoreError(err) {
+			return err
+		}
+		for _, item := range volumes {
+			if !matches(item.Name) {
+				continue
+			}
+			if ok, err := streamCatalogSecurableGrants(ctx, d, h, client, catalogSecurable{Type: "volume", FullName: item.FullName}); !ok {
+				return err
+			}
+		}
+	}
+
+	if wants("function") {
+		functions, err := client.Functions.ListAll(ctx, catalog.ListFunctionsRequest{CatalogName: schema.CatalogName, SchemaName: schema.Name})
+		if err != nil && !ignoreError(err) {
+			return err
+		}
+		for _, item := range functions {
+			if !matches(item.Name) {
+				continue
+			}
+			if ok, err := streamCatalogSecurableGrants(ctx, d, h, client, catalogSecurable{Type: "function", FullName: item.FullName}); !ok {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// streamCatalogSecurableGrants streams the privileges granted directly on a
+// securable, followed by the effective privileges on it when an effective
+// privilege column is requested. Securables dropped or not readable while
+// walking are skipped. It returns false if the walk should stop.
+func streamCatalogSecurableGrants(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, client *databricks.WorkspaceClient, securable catalogSecurable) (bool, error) {
+	principal := d.EqualsQualString("principal")
+
+	grants, err := getCatalogSecurableGrants(ctx, client, securable, principal)
+	if err == nil && wantsCatalogEffectiveGrants(d) {
+		var effectiveGrants []catalogGrantInfo
+		effectiveGrants, err = getCatalogSecurableEffectiveGrants(ctx, client, securable, principal)
+		grants = append(grants, effectiveGrants...)
+	}
+	if err != nil {
+		if shouldIgnoreErrors(catalogWalkIgnoreErrors)(ctx, d, h, err) {
+			return true, nil
+		}
+		return false, err
+	}
+
+	for _, grant := range grants {
+		d.StreamListItem(ctx, grant)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// wantsCatalogEffectiveGrants reports whether the effective privileges are
+// listed, which takes a second API call per securable.
+func wantsCatalogEffectiveGrants(d *plugin.QueryData) bool {
+	for _, column := range catalogGrantEffectiveColumns {
+		if slices.Contains(d.QueryContext.Columns, column) {
+			return true
+		}
+	}
+	return false
+}
+
+// catalogGrantSecurableType returns the type of a securable in the grants
+// API, where views are tables.
+func catalogGrantSecurableType(securable catalogSecurable) catalog.SecurableType {
+	if securable.Type == "view" {
+		return catalog.SecurableTypeTable
+	}
+	return catalog.SecurableType(securable.Type)
+}
+
+// getCatalogSecurableGrants returns the privileges granted directly on a
+// securable.
+func getCatalogSecurableGrants(ctx context.Context, client *databricks.WorkspaceClient, securable catalogSecurable, principal string) ([]catalogGrantInfo, error) {
+	permissions, err := client.Grants.Get(ctx, catalog.GetGrantRequest{
+		SecurableType: catalogGrantSecurableType(securable),
+		FullName:      securable.FullName,
+		Principal:     principal,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var grants []catalogGrantInfo
+	for _, assignment := range permissions.PrivilegeAssignments {
+		for _, privilege := range assignment.Privileges {
+			grants = append(grants, catalogGrantInfo{
+				SecurableType: securable.Type,
+				FullName:      securable.FullName,
+				Principal:     assignment.Principal,
+				Privilege:     string(privilege),
+			})
+		}
+	}
+	return grants, nil
+}
+
+// getCatalogSecurableEffectiveGrants returns the privileges held on a
+// securable, either directly or inherited from a parent securable.
+func getCatalogSecurableEffectiveGrants(ctx context.Context, client *databricks.WorkspaceClient, securable catalogSecurable, principal string) ([]catalogGrantInfo, error) {
+	effectivePermissions, err := client.Grants.GetEffective(ctx, catalog.GetEffectiveRequest{
+		SecurableType: catalogGrantSecurableType(securable),
+		FullName:      securable.FullName,
+		Principal:     principal,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var grants []catalogGrantInfo
+	for _, assignment := range effectivePermissions.PrivilegeAssignments {
+		for _, privilege := range assignment.Privileges {
+			grants = append(grants, catalogGrantInfo{
+				SecurableType:     securable.Type,
+				FullName:          securable.FullName,
+				Principal:         assignment.Principal,
+				Privilege:         string(privilege.Privilege),
+				IsEffective:       true,
+				InheritedFromType: string(privilege.InheritedFromType),
+				InheritedFromName: privilege.InheritedFromName,
+			})
+		}
+	}
+	return grants, nil
+}
@@ -0,0 +1,200 @@
+package databricks
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+var catalogGrantColumns = []string{"securable_type", "full_name", "principal", "privilege", "is_effective", "inherited_from_type", "inherited_from_name"}
+
+func TestListCatalogGrants(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_catalog_grant", catalogGrantColumns, nil, 0)
+
+	var types []string
+	for _, row := range rows {
+		if securableType := row["securable_type"].(string); !slices.Contains(types, securableType) {
+			types = append(types, securableType)
+		}
+	}
+	slices.Sort(types)
+	if want := slices.Sorted(slices.Values(catalogGrantSecurableTypes)); !reflect.DeepEqual(types, want) {
+		t.Errorf("securable_type = %v, want %v", types, want)
+	}
+
+	if got := rowWith(t, rows, "full_name", "main.sales.orders_summary")["securable_type"]; got != "view" {
+		t.Errorf("securable_type of main.sales.orders_summary = %v, want view", got)
+	}
+
+	var orders []map[string]interface{}
+	for _, row := range rows {
+		if row["full_name"] == "main.sales.orders" {
+			orders = append(orders, row)
+		}
+	}
+	if len(orders) != 4 {
+		t.Fatalf("got %d grants on main.sales.orders, want 4: %v", len(orders), orders)
+	}
+	for _, row := range orders {
+		switch row["principal"] {
+		case "bob@example.com":
+			if row["privilege"] != "MODIFY" || row["inherited_from_type"] != nil {
+				t.Errorf("bob@example.com = %v, want MODIFY granted on the table", row)
+			}
+		case "analysts":
+			if row["is_effective"] != true || row["inherited_from_type"] != "schema" || row["inherited_from_name"] != "main.sales" {
+				t.Errorf("analysts = %v, want SELECT inherited from schema main.sales", row)
+			}
+		case "data-engineers":
+			if row["is_effective"] != true || row["inherited_from_type"] != "catalog" || row["inherited_from_name"] != "main" {
+				t.Errorf("data-engineers = %v, want SELECT inherited from catalog main", row)
+			}
+		default:
+			t.Errorf("unexpected grant %v", row)
+		}
+	}
+
+	// The view is a table securable in the grants API
+	if len(fake.requestsTo("/api/2.1/unity-catalog/permissions/table/main.sales.orders_summary")) != 1 {
+		t.Errorf("expected the grants of the view to be read as a table")
+	}
+}
+
+func TestListCatalogGrantsBySecurable(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_catalog_grant", catalogGrantColumns, []*proto.Qual{
+		qual("securable_type", "=", "catalog"),
+		qual("full_name", "=", "main"),
+	}, 0)
+
+	if got, want := columnStrings(rows, "privilege"), []string{"SELECT", "SELECT", "USE_CATALOG", "USE_CATALOG"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("privilege = %v, want %v", got, want)
+	}
+	for _, path := range []string{"/api/2.1/unity-catalog/catalogs", "/api/2.1/unity-catalog/schemas", "/api/2.1/unity-catalog/current-metastore-assignment"} {
+		if len(fake.requestsTo(path)) != 0 {
+			t.Errorf("expected no requests to %s", path)
+		}
+	}
+}
+
+func TestListCatalogGrantsByFullName(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_catalog_grant", catalogGrantColumns, []*proto.Qual{
+		qual("full_name", "=", "main.sales.mask_email"),
+	}, 0)
+
+	if len(rows) != 2 || rows[0]["securable_type"] != "function" || rows[0]["privilege"] != "EXECUTE" {
+		t.Errorf("got %v, want the direct and effective EXECUTE grants on the function", rows)
+	}
+
+	// Only the schema of the securable is listed
+	for _, path := range []string{"/api/2.1/unity-catalog/tables", "/api/2.1/unity-catalog/volumes", "/api/2.1/unity-catalog/functions"} {
+		requests := fake.requestsTo(path)
+		if len(requests) == 0 {
+			t.Errorf("expected requests to %s", path)
+		}
+		for _, r := range requests {
+			if r.Query.Get("catalog_name") != "main" || r.Query.Get("schema_name") != "sales" {
+				t.Errorf("request to %s = %v, want a request for main.sales", path, r)
+			}
+		}
+	}
+	if len(fake.requestsTo("/api/2.1/unity-catalog/catalogs")) != 0 {
+		t.Errorf("expected no catalogs to be listed")
+	}
+}
+
+func TestListCatalogGrantsByPrincipal(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_catalog_grant", catalogGrantColumns, []*proto.Qual{
+		qual("securable_type", "=", "schema"),
+		qual("principal", "=", "data-engineers"),
+	}, 0)
+
+	if len(rows) != 1 || rows[0]["full_name"] != "main.sales" || rows[0]["inherited_from_name"] != "main" {
+		t.Errorf("got %v, want SELECT on main.sales inherited from main", rows)
+	}
+	for _, r := range fake.requestsTo("/api/2.1/unity-catalog/effective-permissions/schema/main.sales") {
+		if r.Query.Get("principal") != "data-engineers" {
+			t.Errorf("principal = %q, want data-engineers", r.Query.Get("principal"))
+		}
+	}
+	if len(fake.requestsTo("/api/2.1/unity-catalog/tables")) != 0 {
+		t.Errorf("expected no tables to be listed for schema grants")
+	}
+}
+
+func TestListCatalogGrantsSkipsInaccessibleSecurables(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	fake.permissionDenied["main.sales"] = true
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_catalog_grant", []string{"full_name", "is_effective"}, []*proto.Qual{
+		qual("securable_type", "=", []string{"schema", "volume"}),
+	}, 0)
+
+	if got, want := columnStrings(rows, "full_name"), []string{"main.sales.landing", "main.sales.landing", "main.sales.landing", "main.sales.landing"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("full_name = %v, want %v", got, want)
+	}
+}
+
+func TestListCatalogGrantsNotFound(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	for _, fullName := range []string{"missing", "main.missing", "main.missing.orders"} {
+		rows := p.query(t, "databricks_catalog_grant", []string{"principal"}, []*proto.Qual{
+			qual("full_name", "=", fullName),
+		}, 0)
+
+		if len(rows) != 0 {
+			t.Errorf("%s: got %d rows, want 0", fullName, len(rows))
+		}
+	}
+}
+
+func TestListCatalogGrantsDirectOnly(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	// Without an effective privilege column, only the direct grants are read
+	rows := p.query(t, "databricks_catalog_grant", []string{"principal", "privilege"}, []*proto.Qual{
+		qual("full_name", "=", "main.sales.orders"),
+	}, 0)
+
+	if len(rows) != 1 || rows[0]["principal"] != "bob@example.com" || rows[0]["privilege"] != "MODIFY" {
+		t.Errorf("got %v, want MODIFY granted to bob@example.com", rows)
+	}
+	if len(fake.requestsTo("/api/2.1/unity-catalog/permissions/table/main.sales.orders")) != 1 {
+		t.Errorf("expected the direct grants of the table to be read")
+	}
+	if got := len(fake.requestsTo("/api/2.1/unity-catalog/effective-permissions/table/main.sales.orders")); got != 0 {
+		t.Errorf("got %d requests for the effective grants, want 0", got)
+	}
+}
+
+func TestListCatalogGrantsWalkConcurrency(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	fake.latency = 100 * time.Millisecond
+	p := newTestPlugin(t, fake, "catalog_walk_concurrency = 1")
+
+	p.query(t, "databricks_catalog_grant", []string{"full_name", "privilege"}, []*proto.Qual{
+		qual("securable_type", "=", []string{"schema", "table"}),
+	}, 0)
+
+	if got := fake.maxRequestsInFlight("/api/2.1/unity-catalog/tables"); got != 1 {
+		t.Errorf("got %d table listings in flight, want 1", got)
+	}
+}
@@ -17,13 +17,34 @@ func tableDatabricksCatalogMetastore(_ context.Context) *plugin.Table {
 		Description: "Gets an array of the available metastores.",
 		List: &plugin.ListConfig{
 			Hydrate: listCatalogMetastores,
+			Tags:    map[string]string{"service": "unity_catalog"},
 		},
 		Get: &plugin.GetConfig{
-			KeyColumns:        plugin.SingleColumn("metastore_id"),
-			ShouldIgnoreError: isNotFoundError([]string{"BAD_REQUEST"}),
-			Hydrate:           getCatalogMetastore,
+			KeyColumns: plugin.SingleColumn("metastore_id"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"BAD_REQUEST"}),
+			},
+			Hydrate: getCatalogMetastore,
+			Tags:    map[string]string{"service": "unity_catalog"},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getCatalogMetastorePermissions,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "unity_catalog"},
+			},
+			{
+				Func: getCatalogMetastoreEffectivePermissions,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "unity_catalog"},
+			},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "metastore_id",
 				Description: "Unique identifier of metastore.",
@@ -0,0 +1,169 @@
+package databricks
+
+import (
+	"context"
+
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableDatabricksCatalogPolicyBinding(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "databricks_catalog_policy_binding",
+		Description: "List the row filter and column mask functions applied to Unity Catalog tables, one per table or column protected.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listCatalogWalkSchemas,
+			Hydrate:       listCatalogPolicyBindings,
+			KeyColumns:    plugin.OptionalColumns([]string{"catalog_name", "schema_name"}),
+			ParentTags:    map[string]string{"service": "unity_catalog"},
+			Tags:          map[string]string{"service": "unity_catalog"},
+		},
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
+			{
+				Name:        "function_full_name",
+				Description: "The full name of the row filter or column mask function, in form of __catalog_name__.__schema_name__.__function_name__.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "policy_type",
+				Description: "The type of the binding, either row_filter or column_mask.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "table_full_name",
+				Description: "Full name of the table the function is applied to, in form of __catalog_name__.__schema_name__.__table_name__.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "catalog_name",
+				Description: "Name of the catalog of the table.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "schema_name",
+				Description: "Name of the schema of the table, relative to its catalog.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "table_name",
+				Description: "Name of the table, relative to its schema.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "column_name",
+				Description: "The name of the column masked by the function, for column masks.",
+				Transform:   transform.FromField("ColumnName").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// JSON fields
+			{
+				Name:        "input_column_names",
+				Description: "The columns of the table passed to the function, in the order of its parameters. For a column mask, the masked column comes first.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Standard Steampipe columns
+			{
+				Name:        "title",
+				Description: "The title of the resource.",
+				Transform:   transform.FromField("FunctionFullName"),
+				Type:        proto.ColumnType_STRING,
+			},
+		}),
+	}
+}
+
+// catalogPolicyBinding is a row filter function applied to a table, or a
+// column mask function applied to a column of a table.
+type catalogPolicyBinding struct {
+	FunctionFullName string
+	PolicyType       string
+	TableFullName    string
+	CatalogName      string
+	SchemaName       string
+	TableName        string
+	ColumnName       string
+	InputColumnNames []string
+}
+
+//// LIST FUNCTION
+
+func listCatalogPolicyBindings(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	parent := h.Item.(catalogWalkItem[catalog.SchemaInfo])
+	schema := parent.Value
+
+	// Bound the number of schemas listed at the same time when walking the metastore
+	done, err := parent.wait(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer done()
+
+	request := catalog.ListTablesRequest{
+		CatalogName: schema.CatalogName,
+		SchemaName:  schema.Name,
+	}
+
+	// Create client
+	client, err := getWorkspaceClient(ctx, d)
+	if err != nil {
+		logger.Error("databricks_catalog_policy_binding.listCatalogPolicyBindings", "connection_error", err)
+		return nil, err
+	}
+
+	tables, err := client.Tables.ListAll(ctx, request)
+	if err != nil {
+		if shouldIgnoreCatalogWalkError(ctx, d, h, err) {
+			return nil, nil
+		}
+		logger.Error("databricks_catalog_policy_binding.listCatalogPolicyBindings", "api_error", err)
+		return nil, err
+	}
+
+	for _, table := range tables {
+		binding := catalogPolicyBinding{
+			TableFullName: table.FullName,
+			CatalogName:   table.CatalogName,
+			SchemaName:    table.SchemaName,
+			TableName:     table.Name,
+		}
+
+		var bindings []catalogPolicyBinding
+		if table.RowFilter != nil && table.RowFilter.Name != "" {
+			rowFilter := binding
+			rowFilter.FunctionFullName = table.RowFilter.Name
+			rowFilter.PolicyType = "row_filter"
+			rowFilter.InputColumnNames = table.RowFilter.InputColumnNames
+			bindings = append(bindings, rowFilter)
+		}
+		for _, column := range table.Columns {
+			if column.Mask == nil || column.Mask.FunctionName == "" {
+				continue
+			}
+			mask := binding
+			mask.FunctionFullName = column.Mask.FunctionName
+			mask.PolicyType = "column_mask"
+			mask.ColumnName = column.Name
+			mask.InputColumnNames = append([]string{column.Name}, column.Mask.UsingColumnNames...)
+			bindings = append(bindings, mask)
+		}
+
+		for _, item := range bindings {
+			d.StreamListItem(ctx, item)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
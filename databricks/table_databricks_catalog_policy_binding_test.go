@@ -0,0 +1,83 @@
+package databricks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+var catalogPolicyBindingColumns = []string{"function_full_name", "policy_type", "table_full_name", "catalog_name", "schema_name", "table_name", "column_name", "input_column_names"}
+
+func TestListCatalogPolicyBindings(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_catalog_policy_binding", catalogPolicyBindingColumns, nil, 0)
+
+	if len(rows) != 3 {
+		t.Fatalf("got %d bindings, want 3", len(rows))
+	}
+
+	rowFilter := rowWith(t, rows, "policy_type", "row_filter")
+	if rowFilter["function_full_name"] != "main.sales.filter_region" || rowFilter["table_full_name"] != "main.sales.orders" || rowFilter["column_name"] != nil {
+		t.Errorf("row filter = %v, want main.sales.filter_region on main.sales.orders", rowFilter)
+	}
+	if got, want := rowFilter["input_column_names"], []interface{}{"customer_id"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("input_column_names of the row filter = %v, want %v", got, want)
+	}
+
+	// The masked column is the first input of a column mask
+	email := rowWith(t, rows, "column_name", "email")
+	if email["policy_type"] != "column_mask" || email["function_full_name"] != "main.sales.mask_email" || email["table_name"] != "customers" {
+		t.Errorf("email = %v, want masked by main.sales.mask_email", email)
+	}
+	if got, want := email["input_column_names"], []interface{}{"email", "customer_id"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("input_column_names of email = %v, want %v", got, want)
+	}
+	if got, want := rowWith(t, rows, "column_name", "ssn")["input_column_names"], []interface{}{"ssn"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("input_column_names of ssn = %v, want %v", got, want)
+	}
+}
+
+func TestListCatalogPolicyBindingsBySchema(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_catalog_policy_binding", catalogPolicyBindingColumns, []*proto.Qual{
+		qual("catalog_name", "=", "main"),
+		qual("schema_name", "=", "default"),
+	}, 0)
+
+	if len(rows) != 0 {
+		t.Errorf("got %d bindings, want 0", len(rows))
+	}
+	if len(fake.requestsTo("/api/2.1/unity-catalog/catalogs")) != 0 || len(fake.requestsTo("/api/2.1/unity-catalog/schemas")) != 0 {
+		t.Errorf("expected no catalogs or schemas to be listed")
+	}
+
+	rows = p.query(t, "databricks_catalog_policy_binding", catalogPolicyBindingColumns, []*proto.Qual{
+		qual("catalog_name", "=", "missing"),
+	}, 0)
+
+	if len(rows) != 0 {
+		t.Errorf("got %d bindings of a missing catalog, want 0", len(rows))
+	}
+}
+
+func TestListCatalogPolicyBindingsNotFound(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	for _, quals := range [][]*proto.Qual{
+		{qual("catalog_name", "=", "missing")},
+		{qual("catalog_name", "=", "missing"), qual("schema_name", "=", "default")},
+		{qual("catalog_name", "=", "main"), qual("schema_name", "=", "missing")},
+	} {
+		rows := p.query(t, "databricks_catalog_policy_binding", []string{"function_full_name"}, quals, 0)
+
+		if len(rows) != 0 {
+			t.Errorf("%v: got %d rows, want 0", quals, len(rows))
+		}
+	}
+}
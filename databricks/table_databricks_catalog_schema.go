@@ -19,13 +19,42 @@ func tableDatabricksCatalogSchema(_ context.Context) *plugin.Table {
 			ParentHydrate: listCatalogs,
 			Hydrate:       listCatalogSchemas,
 			KeyColumns:    plugin.OptionalColumns([]string{"catalog_name"}),
+			ParentTags:    map[string]string{"service": "unity_catalog"},
+			Tags:          map[string]string{"service": "unity_catalog"},
 		},
 		Get: &plugin.GetConfig{
-			KeyColumns:        plugin.SingleColumn("full_name"),
-			ShouldIgnoreError: isNotFoundError([]string{"SCHEMA_DOES_NOT_EXIST"}),
-			Hydrate:           getCatalogSchema,
+			KeyColumns: plugin.SingleColumn("full_name"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"SCHEMA_DOES_NOT_EXIST"}),
+			},
+			Hydrate: getCatalogSchema,
+			Tags:    map[string]string{"service": "unity_catalog"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getCatalogSchemaPermissions,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "unity_catalog"},
+			},
+			{
+				Func: getCatalogSchemaEffectivePermissions,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "unity_catalog"},
+			},
+			{
+				Func: getCatalogSchemaTags,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "unity_catalog"},
+			},
+		},
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "full_name",
 				Description: "Full name of schema, in form of __catalog_name__.__schema_name__.",
@@ -124,6 +153,13 @@ func tableDatabricksCatalogSchema(_ context.Context) *plugin.Table {
 				Hydrate:     getCatalogSchemaEffectivePermissions,
 				Transform:   transform.FromValue(),
 			},
+			{
+				Name:        "tags",
+				Description: "The tags assigned to the schema.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getCatalogSchemaTags,
+				Transform:   transform.FromValue(),
+			},
 
 			// Standard Steampipe columns
 			{
@@ -238,3 +274,22 @@ func getCatalogSchemaEffectivePermissions(ctx context.Context, d *plugin.QueryDa
 	}
 	return permission.PrivilegeAssignments, nil
 }
+
+func getCatalogSchemaTags(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	name := h.Item.(catalog.SchemaInfo).FullName
+
+	// Create client
+	client, err := getWorkspaceAPIClient(ctx, d)
+	if err != nil {
+		logger.Error("databricks_catalog_schema.getCatalogSchemaTags", "connection_error", err)
+		return nil, err
+	}
+
+	assignments, err := listCatalogTagAssignments(ctx, client, "schemas", name)
+	if err != nil {
+		logger.Error("databricks_catalog_schema.getCatalogSchemaTags", "api_error", err)
+		return nil, err
+	}
+	return catalogTagsMap(assignments), nil
+}
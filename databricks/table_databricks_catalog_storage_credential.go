@@ -17,12 +17,31 @@ func tableDatabricksCatalogStorageCredential(_ context.Context) *plugin.Table {
 		Description: "Gets an array of storage credentials.",
 		List: &plugin.ListConfig{
 			Hydrate: listCatalogStorageCredentials,
+			Tags:    map[string]string{"service": "unity_catalog"},
 		},
 		Get: &plugin.GetConfig{
 			KeyColumns: plugin.SingleColumn("name"),
 			Hydrate:    getCatalogStorageCredential,
+			Tags:       map[string]string{"service": "unity_catalog"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getCatalogStorageCredentialPermissions,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "unity_catalog"},
+			},
+			{
+				Func: getCatalogStorageCredentialEffectivePermissions,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "unity_catalog"},
+			},
+		},
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "id",
 				Description: "Unique identifier of the credential.",
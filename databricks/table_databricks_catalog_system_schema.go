@@ -19,8 +19,11 @@ func tableDatabricksCatalogSystemSchema(_ context.Context) *plugin.Table {
 			ParentHydrate: listCatalogMetastores,
 			Hydrate:       listCatalogSystemSchemas,
 			KeyColumns:    plugin.OptionalColumns([]string{"metastore_id"}),
+			ParentTags:    map[string]string{"service": "unity_catalog"},
+			Tags:          map[string]string{"service": "unity_catalog"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "metastore_id",
 				Description: "Unique identifier of parent metastore.",
@@ -2,7 +2,10 @@ package databricks
 
 import (
 	"context"
+	"slices"
+	"strings"
 
+	"github.com/databricks/databricks-sdk-go"
 	"github.com/databricks/databricks-sdk-go/service/catalog"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
@@ -16,16 +19,45 @@ func tableDatabricksCatalogTable(_ context.Context) *plugin.Table {
 		Name:        "databricks_catalog_table",
 		Description: "Gets an array of the available tables.",
 		List: &plugin.ListConfig{
-			Hydrate:           listCatalogTables,
-			ShouldIgnoreError: isNotFoundError([]string{"CATALOG_DOES_NOT_EXIST", "SCHEMA_DOES_NOT_EXIST"}),
-			KeyColumns:        plugin.AllColumns([]string{"catalog_name", "schema_name"}),
+			ParentHydrate: listCatalogTableParents,
+			Hydrate:       listCatalogTables,
+			KeyColumns:    plugin.OptionalColumns([]string{"catalog_name", "schema_name"}),
+			ParentTags:    map[string]string{"service": "unity_catalog"},
+			Tags:          map[string]string{"service": "unity_catalog"},
 		},
 		Get: &plugin.GetConfig{
-			KeyColumns:        plugin.SingleColumn("full_name"),
-			ShouldIgnoreError: isNotFoundError([]string{"TABLE_DOES_NOT_EXIST", "CATALOG_DOES_NOT_EXIST", "SCHEMA_DOES_NOT_EXIST"}),
-			Hydrate:           getCatalogTable,
+			KeyColumns: plugin.SingleColumn("full_name"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"TABLE_DOES_NOT_EXIST", "CATALOG_DOES_NOT_EXIST", "SCHEMA_DOES_NOT_EXIST"}),
+			},
+			Hydrate: getCatalogTable,
+			Tags:    map[string]string{"service": "unity_catalog"},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getCatalogTablePermissions,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "unity_catalog"},
+			},
+			{
+				Func: getCatalogTableEffectivePermissions,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "unity_catalog"},
+			},
+			{
+				Func: getCatalogTableTags,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "unity_catalog"},
+			},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "table_id",
 				Description: "Name of table, relative to parent schema.",
@@ -78,6 +110,12 @@ func tableDatabricksCatalogTable(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_TIMESTAMP,
 				Transform:   transform.FromGo().Transform(transform.UnixMsToTimestamp),
 			},
+			{
+				Name:        "has_column_masks",
+				Description: "True if a column mask function is applied to any column of the table.",
+				Type:        proto.ColumnType_BOOL,
+				Transform:   transform.From(catalogTableHasColumnMasks),
+			},
 			{
 				Name:        "metastore_id",
 				Description: "Unique identifier of parent metastore.",
@@ -93,6 +131,12 @@ func tableDatabricksCatalogTable(_ context.Context) *plugin.Table {
 				Description: "Username of current owner of table.",
 				Type:        proto.ColumnType_STRING,
 			},
+			{
+				Name:        "row_filter_function",
+				Description: "The full name of the row filter function applied to the table, if any.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("RowFilter.Name").Transform(transform.NullIfZeroValue),
+			},
 			{
 				Name:        "sql_path",
 				Description: "List of schemes whose objects can be referenced without qualification.",
@@ -175,6 +219,13 @@ func tableDatabricksCatalogTable(_ context.Context) *plugin.Table {
 				Description: "View dependencies associated with the table.",
 				Type:        proto.ColumnType_JSON,
 			},
+			{
+				Name:        "tags",
+				Description: "The tags assigned to the table.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getCatalogTableTags,
+				Transform:   transform.FromValue(),
+			},
 
 			// Standard Steampipe columns
 			{
@@ -187,15 +238,57 @@ func tableDatabricksCatalogTable(_ context.Context) *plugin.Table {
 	}
 }
 
+// catalogTableSummaryColumns are the columns of databricks_catalog_table
+// returned by the table summaries API.
+var catalogTableSummaryColumns = map[string]bool{"full_name": true, "catalog_name": true, "schema_name": true, "name": true, "table_type": true, "title": true}
+
 //// LIST FUNCTION
 
+// listCatalogTableParents streams the schemas whose tables are listed, or the
+// catalogs whose table summaries are listed when the use_table_summaries
+// connection argument is set and only summary columns are selected.
+func listCatalogTableParents(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	if useCatalogTableSummaries(d) {
+		return listCatalogWalkCatalogs(ctx, d, h)
+	}
+	return listCatalogWalkSchemas(ctx, d, h)
+}
+
 func listCatalogTables(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	logger := plugin.Logger(ctx)
 
-	if d.EqualsQualString("catalog_name") == "" || d.EqualsQualString("schema_name") == "" {
-		return nil, nil
+	// Create client
+	client, err := getWorkspaceClient(ctx, d)
+	if err != nil {
+		logger.Error("databricks_catalog_table.listCatalogTables", "connection_error", err)
+		return nil, err
+	}
+
+	// Bound the number of schemas, or catalogs, listed at the same time when
+	// walking the metastore
+	done, err := h.Item.(catalogWalkParent).wait(ctx)
+	if err != nil {
+		return nil, err
 	}
+	defer done()
 
+	switch parent := h.Item.(type) {
+	case catalogWalkItem[catalog.CatalogInfo]:
+		err = listCatalogTableSummaries(ctx, d, client, parent.Value.Name)
+	case catalogWalkItem[catalog.SchemaInfo]:
+		err = listCatalogSchemaTables(ctx, d, client, parent.Value)
+	}
+	if err != nil {
+		if shouldIgnoreCatalogWalkError(ctx, d, h, err) {
+			return nil, nil
+		}
+		logger.Error("databricks_catalog_table.listCatalogTables", "api_error", err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+func listCatalogSchemaTables(ctx context.Context, d *plugin.QueryData, client *databricks.WorkspaceClient, schema catalog.SchemaInfo) error {
 	// Limiting the results
 	maxLimit := 1000
 	if d.QueryContext.Limit != nil {
@@ -208,22 +301,14 @@ func listCatalogTables(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 	request := catalog.ListTablesRequest{
 		MaxResults:           maxLimit,
 		IncludeDeltaMetadata: true,
-		CatalogName:          d.EqualsQualString("catalog_name"),
-		SchemaName:           d.EqualsQualString("schema_name"),
-	}
-
-	// Create client
-	client, err := getWorkspaceClient(ctx, d)
-	if err != nil {
-		logger.Error("databricks_catalog_table.listCatalogTables", "connection_error", err)
-		return nil, err
+		CatalogName:          schema.CatalogName,
+		SchemaName:           schema.Name,
 	}
 
 	for {
 		response, err := client.Tables.Impl().List(ctx, request)
 		if err != nil {
-			logger.Error("databricks_catalog_table.listCatalogTables", "api_error", err)
-			return nil, err
+			return err
 		}
 
 		for _, item := range response.Tables {
@@ -231,17 +316,79 @@ func listCatalogTables(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 
 			// Context can be cancelled due to manual cancellation or if the limit has been hit
 			if d.RowsRemaining(ctx) == 0 {
-				return nil, nil
+				return nil
 			}
 		}
 
 		if response.NextPageToken == "" {
-			return nil, nil
+			return nil
 		}
 		request.PageToken = response.NextPageToken
 	}
 }
 
+// listCatalogTableSummaries streams the tables of a catalog, with only the
+// columns returned by the table summaries API, from a single paged request.
+func listCatalogTableSummaries(ctx context.Context, d *plugin.QueryData, client *databricks.WorkspaceClient, catalogName string) error {
+	schemaName := d.EqualsQualString("schema_name")
+
+	// The schema name is a LIKE pattern, so the tables of similarly named
+	// schemas are filtered out below
+	request := catalog.ListSummariesRequest{
+		CatalogName:       catalogName,
+		SchemaNamePattern: schemaName,
+	}
+
+	for {
+		response, err := client.Tables.Impl().ListSummaries(ctx, request)
+		if err != nil {
+			return err
+		}
+
+		for _, summary := range response.Tables {
+			names := strings.SplitN(summary.FullName, ".", 3)
+			if len(names) != 3 || (schemaName != "" && names[1] != schemaName) {
+				continue
+			}
+			d.StreamListItem(ctx, catalog.TableInfo{
+				FullName:    summary.FullName,
+				CatalogName: names[0],
+				SchemaName:  names[1],
+				Name:        names[2],
+				TableType:   summary.TableType,
+			})
+
+			// Context can be cancelled due to manual cancellation or if the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil
+			}
+		}
+
+		if response.NextPageToken == "" {
+			return nil
+		}
+		request.PageToken = response.NextPageToken
+	}
+}
+
+// useCatalogTableSummaries reports whether the tables of the metastore are
+// listed from the table summaries API. Besides the use_table_summaries
+// connection argument, this requires the selected columns to be summary
+// columns, or columns fetched by their own hydrate such as permissions.
+func useCatalogTableSummaries(d *plugin.QueryData) bool {
+	config := GetConfig(d.Connection)
+	if config.UseTableSummaries == nil || !*config.UseTableSummaries || !isCatalogWalk(d) {
+		return false
+	}
+
+	for _, column := range d.Table.Columns {
+		if column.Hydrate == nil && !catalogTableSummaryColumns[column.Name] && slices.Contains(d.QueryContext.Columns, column.Name) {
+			return false
+		}
+	}
+	return true
+}
+
 //// HYDRATE FUNCTIONS
 
 func getCatalogTable(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
@@ -310,3 +457,33 @@ func getCatalogTableEffectivePermissions(ctx context.Context, d *plugin.QueryDat
 	}
 	return permission.PrivilegeAssignments, nil
 }
+
+func getCatalogTableTags(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	name := h.Item.(catalog.TableInfo).FullName
+
+	// Create client
+	client, err := getWorkspaceAPIClient(ctx, d)
+	if err != nil {
+		logger.Error("databricks_catalog_table.getCatalogTableTags", "connection_error", err)
+		return nil, err
+	}
+
+	assignments, err := listCatalogTagAssignments(ctx, client, "tables", name)
+	if err != nil {
+		logger.Error("databricks_catalog_table.getCatalogTableTags", "api_error", err)
+		return nil, err
+	}
+	return catalogTagsMap(assignments), nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func catalogTableHasColumnMasks(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	for _, column := range d.HydrateItem.(catalog.TableInfo).Columns {
+		if column.Mask != nil && column.Mask.FunctionName != "" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,325 @@
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/databricks/databricks-sdk-go/client"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Error codes returned when the table, catalog or schema given by the quals
+// does not exist
+var catalogTableColumnNotFoundErrors = []string{"TABLE_DOES_NOT_EXIST", "CATALOG_DOES_NOT_EXIST", "SCHEMA_DOES_NOT_EXIST"}
+
+//// TABLE DEFINITION
+
+func tableDatabricksCatalogTableColumn(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "databricks_catalog_table_column",
+		Description: "List the columns of Unity Catalog tables and views.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listCatalogTableColumnParents,
+			Hydrate:       listCatalogTableColumns,
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors(catalogTableColumnNotFoundErrors),
+			},
+			KeyColumns: plugin.OptionalColumns([]string{"catalog_name", "schema_name", "table_full_name"}),
+			ParentTags: map[string]string{"service": "unity_catalog"},
+			Tags:       map[string]string{"service": "unity_catalog"},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getCatalogTableColumnTags,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "unity_catalog"},
+			},
+		},
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
+			{
+				Name:        "table_full_name",
+				Description: "Full name of the table of the column, in form of __catalog_name__.__schema_name__.__table_name__.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "catalog_name",
+				Description: "Name of the catalog of the table.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "schema_name",
+				Description: "Name of the schema of the table, relative to its catalog.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "table_name",
+				Description: "Name of the table, relative to its schema.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "table_type",
+				Description: "The type of the table, e.g. MANAGED, EXTERNAL or VIEW.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "name",
+				Description: "Name of the column.",
+				Transform:   transform.FromField("Column.Name"),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "position",
+				Description: "Ordinal position of the column in the table, starting at 0.",
+				Transform:   transform.FromField("Column.Position"),
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "type_name",
+				Description: "The data type of the column, e.g. STRING, DECIMAL or ARRAY.",
+				Transform:   transform.FromField("Column.TypeName").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "type_text",
+				Description: "The full data type of the column in SQL, e.g. decimal(10,2) or array<string>.",
+				Transform:   transform.FromField("Column.TypeText").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "type_precision",
+				Description: "Digits of precision of a DECIMAL column.",
+				Transform:   transform.FromField("Column.TypePrecision").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "type_scale",
+				Description: "Digits to the right of the decimal point of a DECIMAL column.",
+				Transform:   transform.FromField("Column.TypeScale"),
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "type_interval_type",
+				Description: "The format of an INTERVAL column.",
+				Transform:   transform.FromField("Column.TypeIntervalType").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "nullable",
+				Description: "Whether the column may contain null values.",
+				Transform:   transform.FromField("Column.Nullable"),
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "partition_index",
+				Description: "Position of the column among the partition columns of the table, starting at 0, or null if the table is not partitioned by the column.",
+				Transform:   transform.FromField("Column.PartitionIndex"),
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "comment",
+				Description: "User-provided free-form text description.",
+				Transform:   transform.FromField("Column.Comment").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "mask",
+				Description: "The full name of the SQL function masking the values of the column, if any.",
+				Transform:   transform.FromField("Column.Mask.FunctionName").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// JSON fields
+			{
+				Name:        "mask_using_column_names",
+				Description: "The additional columns of the table passed to the column mask function.",
+				Transform:   transform.FromField("Column.Mask.UsingColumnNames"),
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "type_json",
+				Description: "The data type of the column as a JSON string, including the fields of a STRUCT.",
+				Transform:   transform.FromField("Column.TypeJson").Transform(transform.NullIfZeroValue).Transform(transform.UnmarshalJSON),
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "tags",
+				Description: "The tags assigned to the column, as shown in the column_tags view of the Information Schema.",
+				Hydrate:     getCatalogTableColumnTags,
+				Transform:   transform.FromValue(),
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Standard Steampipe columns
+			{
+				Name:        "title",
+				Description: "The title of the resource.",
+				Transform:   transform.FromField("Column.Name"),
+				Type:        proto.ColumnType_STRING,
+			},
+		}),
+	}
+}
+
+// catalogTableColumns is a table with its columns, as returned by the tables
+// API.
+type catalogTableColumns struct {
+	Name        string              `json:"name"`
+	CatalogName string              `json:"catalog_name"`
+	SchemaName  string              `json:"schema_name"`
+	FullName    string              `json:"full_name"`
+	TableType   string              `json:"table_type"`
+	Columns     []catalogColumnInfo `json:"columns"`
+}
+
+// catalogColumnInfo is the column of a table. The partition index is only
+// returned for partition columns, of which the first has index 0, so unlike
+// catalog.ColumnInfo it is kept as a pointer.
+type catalogColumnInfo struct {
+	catalog.ColumnInfo
+	PartitionIndex *int `json:"partition_index,omitempty"`
+}
+
+type listCatalogTableColumnsResponse struct {
+	Tables        []catalogTableColumns `json:"tables"`
+	NextPageToken string                `json:"next_page_token,omitempty"`
+}
+
+// catalogTableColumnInfo is a column of a table.
+type catalogTableColumnInfo struct {
+	TableFullName string
+	CatalogName   string
+	SchemaName    string
+	TableName     string
+	TableType     string
+	Column        catalogColumnInfo
+}
+
+//// LIST FUNCTION
+
+// listCatalogTableColumnParents streams the table given by the
+// table_full_name qual, or else the schemas whose tables are walked.
+func listCatalogTableColumnParents(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	if fullName := d.EqualsQualString("table_full_name"); fullName != "" {
+		d.StreamListItem(ctx, catalog.TableInfo{FullName: fullName})
+		return nil, nil
+	}
+	return listCatalogWalkSchemas(ctx, d, h)
+}
+
+func listCatalogTableColumns(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+
+	// Create client
+	client, err := getWorkspaceAPIClient(ctx, d)
+	if err != nil {
+		logger.Error("databricks_catalog_table_column.listCatalogTableColumns", "connection_error", err)
+		return nil, err
+	}
+
+	switch parent := h.Item.(type) {
+	case catalog.TableInfo:
+		var table catalogTableColumns
+		err = client.Do(ctx, http.MethodGet, "/api/2.1/unity-catalog/tables/"+url.PathEscape(parent.FullName), nil, &table)
+		if err == nil {
+			streamCatalogTableColumns(ctx, d, table)
+		}
+	case catalogWalkItem[catalog.SchemaInfo]:
+		// Bound the number of schemas listed at the same time when walking the metastore
+		done, waitErr := parent.wait(ctx)
+		if waitErr != nil {
+			return nil, waitErr
+		}
+		defer done()
+		err = listCatalogSchemaTableColumns(ctx, d, client, parent.Value)
+	}
+	if err != nil {
+		// The ignore config of the list only applies to the parent hydrate
+		if shouldIgnoreErrors(catalogTableColumnNotFoundErrors)(ctx, d, h, err) {
+			return nil, nil
+		}
+		// Catalogs and schemas dropped or not readable while walking are skipped
+		if isCatalogWalk(d) && d.EqualsQualString("table_full_name") == "" && shouldIgnoreErrors(catalogWalkIgnoreErrors)(ctx, d, h, err) {
+			return nil, nil
+		}
+		logger.Error("databricks_catalog_table_column.listCatalogTableColumns", "api_error", err)
+		return nil, err
+	}
+	return nil, nil
+}
+
+func listCatalogSchemaTableColumns(ctx context.Context, d *plugin.QueryData, client *client.DatabricksClient, schema catalog.SchemaInfo) error {
+	params := url.Values{}
+	params.Set("catalog_name", schema.CatalogName)
+	params.Set("schema_name", schema.Name)
+	params.Set("max_results", strconv.Itoa(1000))
+
+	for {
+		var response listCatalogTableColumnsResponse
+		err := client.Do(ctx, http.MethodGet, "/api/2.1/unity-catalog/tables?"+params.Encode(), nil, &response)
+		if err != nil {
+			return err
+		}
+
+		for _, table := range response.Tables {
+			if !streamCatalogTableColumns(ctx, d, table) {
+				return nil
+			}
+		}
+
+		if response.NextPageToken == "" {
+			return nil
+		}
+		params.Set("page_token", response.NextPageToken)
+	}
+}
+
+// streamCatalogTableColumns streams the columns of a table, and returns false
+// once the limit has been hit.
+func streamCatalogTableColumns(ctx context.Context, d *plugin.QueryData, table catalogTableColumns) bool {
+	for _, column := range table.Columns {
+		d.StreamListItem(ctx, catalogTableColumnInfo{
+			TableFullName: table.FullName,
+			CatalogName:   table.CatalogName,
+			SchemaName:    table.SchemaName,
+			TableName:     table.Name,
+			TableType:     table.TableType,
+			Column:        column,
+		})
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+//// HYDRATE FUNCTIONS
+
+func getCatalogTableColumnTags(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	column := h.Item.(catalogTableColumnInfo)
+
+	// Create client
+	client, err := getWorkspaceAPIClient(ctx, d)
+	if err != nil {
+		logger.Error("databricks_catalog_table_column.getCatalogTableColumnTags", "connection_error", err)
+		return nil, err
+	}
+
+	assignments, err := listCatalogTagAssignments(ctx, client, "columns", fmt.Sprintf("%s.%s", column.TableFullName, column.Column.Name))
+	if err != nil {
+		logger.Error("databricks_catalog_table_column.getCatalogTableColumnTags", "api_error", err)
+		return nil, err
+	}
+	return catalogTagsMap(assignments), nil
+}
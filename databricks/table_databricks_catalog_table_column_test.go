@@ -0,0 +1,139 @@
+package databricks
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestListCatalogTableColumnsByTable(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_catalog_table_column", []string{"table_full_name", "schema_name", "name", "position", "type_name", "type_text", "type_precision", "type_scale", "nullable", "partition_index", "type_json"}, []*proto.Qual{
+		qual("table_full_name", "=", "main.sales.orders"),
+	}, 0)
+
+	if got, want := columnStrings(rows, "name"), []string{"amount", "customer_id", "order_date", "order_id"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("name = %v, want %v", got, want)
+	}
+
+	amount := rowWith(t, rows, "name", "amount")
+	if amount["type_name"] != "DECIMAL" || amount["type_text"] != "decimal(10,2)" || amount["type_precision"] != int64(10) || amount["type_scale"] != int64(2) || amount["position"] != int64(2) {
+		t.Errorf("amount = %v, want a decimal(10,2) at position 2", amount)
+	}
+	if typeJson, ok := amount["type_json"].(map[string]interface{}); !ok || typeJson["name"] != "amount" {
+		t.Errorf("type_json = %v, want the parsed type of amount", amount["type_json"])
+	}
+
+	// The first partition column has index 0, while other columns have none
+	if got := rowWith(t, rows, "name", "order_date")["partition_index"]; got != int64(0) {
+		t.Errorf("partition_index of order_date = %v, want 0", got)
+	}
+	if got := rowWith(t, rows, "name", "order_id")["partition_index"]; got != nil {
+		t.Errorf("partition_index of order_id = %v, want nil", got)
+	}
+	if got := rowWith(t, rows, "name", "order_id")["nullable"]; got != false {
+		t.Errorf("nullable of order_id = %v, want false", got)
+	}
+
+	if len(fake.requestsTo("/api/2.1/unity-catalog/tables")) != 0 || len(fake.requestsTo("/api/2.1/unity-catalog/tables/main.sales.orders")) != 1 {
+		t.Errorf("expected the table to be fetched by name without listing")
+	}
+}
+
+func TestListCatalogTableColumnsMasksAndTags(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_catalog_table_column", []string{"name", "comment", "mask", "mask_using_column_names", "tags"}, []*proto.Qual{
+		qual("table_full_name", "=", "main.sales.customers"),
+	}, 0)
+
+	email := rowWith(t, rows, "name", "email")
+	if email["mask"] != "main.sales.mask_email" || email["comment"] != "Contact email" || !reflect.DeepEqual(email["mask_using_column_names"], []interface{}{"customer_id"}) {
+		t.Errorf("email = %v, want masked by main.sales.mask_email using customer_id", email)
+	}
+	if got, want := rowWith(t, rows, "name", "ssn")["tags"], map[string]interface{}{"pii": "ssn", "classification": "restricted"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("tags of ssn = %v, want %v", got, want)
+	}
+
+	customerId := rowWith(t, rows, "name", "customer_id")
+	if customerId["mask"] != nil || customerId["comment"] != nil || !reflect.DeepEqual(customerId["tags"], map[string]interface{}{}) {
+		t.Errorf("customer_id = %v, want no mask, comment or tags", customerId)
+	}
+
+	if got := len(fake.requestsTo("/api/2.1/unity-catalog/entity-tag-assignments/columns/main.sales.customers.ssn/tags")); got != 1 {
+		t.Errorf("got %d requests for the tags of ssn, want 1", got)
+	}
+}
+
+func TestListCatalogTableColumnsAllTables(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_catalog_table_column", []string{"table_full_name", "name"}, nil, 0)
+
+	if len(rows) != 13 {
+		t.Errorf("got %d columns, want 13", len(rows))
+	}
+	if got := rowWith(t, rows, "name", "id")["table_full_name"]; got != "sandbox.scratch.tmp" {
+		t.Errorf("table_full_name of id = %v, want sandbox.scratch.tmp", got)
+	}
+
+	// main.sales has three tables, so takes two pages
+	if got := len(fake.requestsTo("/api/2.1/unity-catalog/tables")); got != 4 {
+		t.Errorf("got %d requests to list tables, want 4", got)
+	}
+}
+
+func TestListCatalogTableColumnsWalkConcurrency(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	fake.latency = 100 * time.Millisecond
+	p := newTestPlugin(t, fake, "catalog_walk_concurrency = 1")
+
+	rows := p.query(t, "databricks_catalog_table_column", []string{"table_full_name", "name"}, nil, 0)
+
+	if len(rows) != 13 {
+		t.Errorf("got %d columns, want 13", len(rows))
+	}
+	if got := fake.maxRequestsInFlight("/api/2.1/unity-catalog/tables"); got != 1 {
+		t.Errorf("got %d concurrent requests to list tables, want 1", got)
+	}
+}
+
+func TestListCatalogTableColumnsByCatalog(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_catalog_table_column", []string{"table_full_name", "name"}, []*proto.Qual{
+		qual("catalog_name", "=", "main"),
+		qual("schema_name", "=", "default"),
+	}, 0)
+
+	if got, want := columnStrings(rows, "name"), []string{"event_date", "event_id", "payload"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("name = %v, want %v", got, want)
+	}
+	if len(fake.requestsTo("/api/2.1/unity-catalog/catalogs")) != 0 || len(fake.requestsTo("/api/2.1/unity-catalog/schemas")) != 0 {
+		t.Errorf("expected no catalogs or schemas to be listed")
+	}
+}
+
+func TestListCatalogTableColumnsNotFound(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	for _, quals := range [][]*proto.Qual{
+		{qual("table_full_name", "=", "main.sales.missing")},
+		{qual("catalog_name", "=", "missing")},
+		{qual("catalog_name", "=", "main"), qual("schema_name", "=", "missing")},
+	} {
+		rows := p.query(t, "databricks_catalog_table_column", []string{"name"}, quals, 0)
+
+		if len(rows) != 0 {
+			t.Errorf("%v: got %d rows, want 0", quals, len(rows))
+		}
+	}
+}
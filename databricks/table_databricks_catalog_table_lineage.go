@@ -0,0 +1,310 @@
+package databricks
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableDatabricksCatalogTableLineage(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "databricks_catalog_table_lineage",
+		Description: "Get the upstream and downstream tables, notebooks, jobs, queries and dashboards of a table.",
+		List: &plugin.ListConfig{
+			Hydrate: listCatalogTableLineage,
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"TABLE_DOES_NOT_EXIST", "CATALOG_DOES_NOT_EXIST", "SCHEMA_DOES_NOT_EXIST"}),
+			},
+			KeyColumns: plugin.SingleColumn("table_full_name"),
+			Tags:       map[string]string{"service": "unity_catalog"},
+		},
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
+			{
+				Name:        "table_full_name",
+				Description: "Full name of the table whose lineage is returned, in form of __catalog_name__.__schema_name__.__table_name__.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "direction",
+				Description: "Whether the entity is upstream of the table, i.e. the table is read from it, or downstream of it, i.e. it reads from the table.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "entity_type",
+				Description: "The type of the entity, one of table, notebook, job, query, dashboard or file.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "entity_id",
+				Description: "The identifier of the entity: the full name of a table, the ID of a notebook, job, query or dashboard, or the path of a file.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "entity_name",
+				Description: "The name of a table, relative to its schema.",
+				Transform:   transform.FromField("EntityName").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "entity_catalog_name",
+				Description: "The name of the catalog of a table.",
+				Transform:   transform.FromField("EntityCatalogName").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "entity_schema_name",
+				Description: "The name of the schema of a table.",
+				Transform:   transform.FromField("EntitySchemaName").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "entity_table_type",
+				Description: "The type of a table, e.g. TABLE, VIEW or PATH.",
+				Transform:   transform.FromField("EntityTableType").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "entity_workspace_id",
+				Description: "The ID of the workspace of a notebook, job, query or dashboard.",
+				Transform:   transform.FromField("EntityWorkspaceId").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "lineage_timestamp",
+				Description: "The time the lineage between the table and the entity was last observed.",
+				Transform:   transform.FromField("LineageTimestamp").Transform(lineageTimestampToTime),
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+
+			// JSON fields
+			{
+				Name:        "entity",
+				Description: "The entity as returned by the lineage API.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Standard Steampipe columns
+			{
+				Name:        "title",
+				Description: "The title of the resource.",
+				Transform:   transform.FromField("EntityId"),
+				Type:        proto.ColumnType_STRING,
+			},
+		}),
+	}
+}
+
+// tableLineageResponse is the response of the table lineage API. Each
+// upstream or downstream holds either a table, a file, or the notebooks,
+// jobs, queries and dashboards reading or writing the table.
+type tableLineageResponse struct {
+	Upstreams   []tableLineageEntities `json:"upstreams"`
+	Downstreams []tableLineageEntities `json:"downstreams"`
+}
+
+type tableLineageEntities struct {
+	TableInfo      *lineageTableInfo      `json:"tableInfo,omitempty"`
+	FileInfo       *lineageFileInfo       `json:"fileInfo,omitempty"`
+	NotebookInfos  []lineageNotebookInfo  `json:"notebookInfos,omitempty"`
+	JobInfos       []lineageJobInfo       `json:"jobInfos,omitempty"`
+	QueryInfos     []lineageQueryInfo     `json:"queryInfos,omitempty"`
+	DashboardInfos []lineageDashboardInfo `json:"dashboardInfos,omitempty"`
+}
+
+type lineageTableInfo struct {
+	Name             string `json:"name"`
+	CatalogName      string `json:"catalog_name"`
+	SchemaName       string `json:"schema_name"`
+	TableType        string `json:"table_type,omitempty"`
+	LineageTimestamp string `json:"lineage_timestamp,omitempty"`
+}
+
+type lineageFileInfo struct {
+	Path             string `json:"path"`
+	HasPermission    bool   `json:"has_permission,omitempty"`
+	SecurableName    string `json:"securable_name,omitempty"`
+	SecurableType    string `json:"securable_type,omitempty"`
+	StorageLocation  string `json:"storage_location,omitempty"`
+	LineageTimestamp string `json:"lineage_timestamp,omitempty"`
+}
+
+type lineageNotebookInfo struct {
+	WorkspaceId      int64  `json:"workspace_id"`
+	NotebookId       int64  `json:"notebook_id"`
+	LineageTimestamp string `json:"lineage_timestamp,omitempty"`
+}
+
+type lineageJobInfo struct {
+	WorkspaceId      int64  `json:"workspace_id"`
+	JobId            int64  `json:"job_id"`
+	LineageTimestamp string `json:"lineage_timestamp,omitempty"`
+}
+
+type lineageQueryInfo struct {
+	WorkspaceId      int64  `json:"workspace_id"`
+	QueryId          string `json:"query_id"`
+	LineageTimestamp string `json:"lineage_timestamp,omitempty"`
+}
+
+type lineageDashboardInfo struct {
+	WorkspaceId      int64  `json:"workspace_id"`
+	DashboardId      string `json:"dashboard_id"`
+	LineageTimestamp string `json:"lineage_timestamp,omitempty"`
+}
+
+// catalogTableLineageInfo is an entity upstream or downstream of a table.
+type catalogTableLineageInfo struct {
+	TableFullName     string
+	Direction         string
+	EntityType        string
+	EntityId          string
+	EntityName        string
+	EntityCatalogName string
+	EntitySchemaName  string
+	EntityTableType   string
+	EntityWorkspaceId int64
+	LineageTimestamp  string
+	Entity            interface{}
+}
+
+//// LIST FUNCTION
+
+func listCatalogTableLineage(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	tableFullName := d.EqualsQualString("table_full_name")
+
+	// Create client
+	client, err := getWorkspaceAPIClient(ctx, d)
+	if err != nil {
+		logger.Error("databricks_catalog_table_lineage.listCatalogTableLineage", "connection_error", err)
+		return nil, err
+	}
+
+	params := url.Values{}
+	params.Set("table_name", tableFullName)
+	params.Set("include_entity_lineage", "true")
+
+	var response tableLineageResponse
+	err = client.Do(ctx, http.MethodGet, "/api/2.0/lineage-tracking/table-lineage?"+params.Encode(), nil, &response)
+	if err != nil {
+		logger.Error("databricks_catalog_table_lineage.listCatalogTableLineage", "api_error", err)
+		return nil, err
+	}
+
+	var items []catalogTableLineageInfo
+	for _, entities := range response.Upstreams {
+		items = append(items, flattenTableLineage(tableFullName, "upstream", entities)...)
+	}
+	for _, entities := range response.Downstreams {
+		items = append(items, flattenTableLineage(tableFullName, "downstream", entities)...)
+	}
+
+	for _, item := range items {
+		d.StreamListItem(ctx, item)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// flattenTableLineage returns a row for each entity of an upstream or
+// downstream of a table.
+func flattenTableLineage(tableFullName, direction string, entities tableLineageEntities) []catalogTableLineageInfo {
+	var items []catalogTableLineageInfo
+	add := func(item catalogTableLineageInfo) {
+		item.TableFullName = tableFullName
+		item.Direction = direction
+		items = append(items, item)
+	}
+
+	if table := entities.TableInfo; table != nil {
+		add(catalogTableLineageInfo{
+			EntityType:        "table",
+			EntityId:          fmt.Sprintf("%s.%s.%s", table.CatalogName, table.SchemaName, table.Name),
+			EntityName:        table.Name,
+			EntityCatalogName: table.CatalogName,
+			EntitySchemaName:  table.SchemaName,
+			EntityTableType:   table.TableType,
+			LineageTimestamp:  table.LineageTimestamp,
+			Entity:            table,
+		})
+	}
+	if file := entities.FileInfo; file != nil {
+		add(catalogTableLineageInfo{
+			EntityType:       "file",
+			EntityId:         file.Path,
+			LineageTimestamp: file.LineageTimestamp,
+			Entity:           file,
+		})
+	}
+	for _, notebook := range entities.NotebookInfos {
+		add(catalogTableLineageInfo{
+			EntityType:        "notebook",
+			EntityId:          strconv.FormatInt(notebook.NotebookId, 10),
+			EntityWorkspaceId: notebook.WorkspaceId,
+			LineageTimestamp:  notebook.LineageTimestamp,
+			Entity:            notebook,
+		})
+	}
+	for _, job := range entities.JobInfos {
+		add(catalogTableLineageInfo{
+			EntityType:        "job",
+			EntityId:          strconv.FormatInt(job.JobId, 10),
+			EntityWorkspaceId: job.WorkspaceId,
+			LineageTimestamp:  job.LineageTimestamp,
+			Entity:            job,
+		})
+	}
+	for _, query := range entities.QueryInfos {
+		add(catalogTableLineageInfo{
+			EntityType:        "query",
+			EntityId:          query.QueryId,
+			EntityWorkspaceId: query.WorkspaceId,
+			LineageTimestamp:  query.LineageTimestamp,
+			Entity:            query,
+		})
+	}
+	for _, dashboard := range entities.DashboardInfos {
+		add(catalogTableLineageInfo{
+			EntityType:        "dashboard",
+			EntityId:          dashboard.DashboardId,
+			EntityWorkspaceId: dashboard.WorkspaceId,
+			LineageTimestamp:  dashboard.LineageTimestamp,
+			Entity:            dashboard,
+		})
+	}
+	return items
+}
+
+//// TRANSFORM FUNCTIONS
+
+// lineageTimestampLayouts are the formats of lineage timestamps, which are
+// returned in UTC without a time zone, e.g. 2023-10-01 09:30:00.0.
+var lineageTimestampLayouts = []string{"2006-01-02 15:04:05.999999999", time.RFC3339Nano}
+
+func lineageTimestampToTime(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	timestamp, ok := d.Value.(string)
+	if !ok || timestamp == "" {
+		return nil, nil
+	}
+	for _, layout := range lineageTimestampLayouts {
+		if t, err := time.Parse(layout, timestamp); err == nil {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
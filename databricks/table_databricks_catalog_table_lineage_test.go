@@ -0,0 +1,70 @@
+package databricks
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestListCatalogTableLineage(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_catalog_table_lineage", []string{"table_full_name", "direction", "entity_type", "entity_id", "entity_name", "entity_table_type", "entity_workspace_id", "lineage_timestamp"}, []*proto.Qual{
+		qual("table_full_name", "=", "main.sales.orders"),
+	}, 0)
+
+	if got, want := columnStrings(rows, "entity_id"), []string{"01ee5f6a1b2c3d4e", "11", "4242", "main.bronze.raw_orders", "main.gold.daily_revenue", "s3://landing/orders/"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("entity_id = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		entityId, direction, entityType string
+	}{
+		{"main.bronze.raw_orders", "upstream", "table"},
+		{"s3://landing/orders/", "upstream", "file"},
+		{"4242", "downstream", "notebook"},
+		{"11", "downstream", "job"},
+		{"01ee5f6a1b2c3d4e", "downstream", "dashboard"},
+		{"main.gold.daily_revenue", "downstream", "table"},
+	}
+	for _, tt := range tests {
+		row := rowWith(t, rows, "entity_id", tt.entityId)
+		if row["direction"] != tt.direction || row["entity_type"] != tt.entityType || row["table_full_name"] != "main.sales.orders" {
+			t.Errorf("%s = %v, want a %s %s", tt.entityId, row, tt.direction, tt.entityType)
+		}
+	}
+
+	view := rowWith(t, rows, "entity_id", "main.gold.daily_revenue")
+	if view["entity_name"] != "daily_revenue" || view["entity_table_type"] != "VIEW" || view["entity_workspace_id"] != nil {
+		t.Errorf("daily_revenue = %v, want a view without a workspace", view)
+	}
+	if got, want := view["lineage_timestamp"], time.Date(2023, 10, 2, 11, 5, 0, 0, time.UTC); got != want {
+		t.Errorf("lineage_timestamp = %v, want %v", got, want)
+	}
+	if got := rowWith(t, rows, "entity_id", "4242")["entity_workspace_id"]; got != int64(fakeWorkspaceId) {
+		t.Errorf("entity_workspace_id = %v, want %d", got, fakeWorkspaceId)
+	}
+
+	requests := fake.requestsTo("/api/2.0/lineage-tracking/table-lineage")
+	if len(requests) != 1 || requests[0].Query.Get("table_name") != "main.sales.orders" || requests[0].Query.Get("include_entity_lineage") != "true" {
+		t.Errorf("requests = %v, want a single request for main.sales.orders including entities", requests)
+	}
+}
+
+func TestListCatalogTableLineageNoLineage(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	for _, table := range []string{"main.sales.returns", "main.sales.missing"} {
+		rows := p.query(t, "databricks_catalog_table_lineage", []string{"entity_id"}, []*proto.Qual{
+			qual("table_full_name", "=", table),
+		}, 0)
+
+		if len(rows) != 0 {
+			t.Errorf("%s: got %d rows, want 0", table, len(rows))
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package databricks
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestListCatalogTablesBySchema(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_catalog_table", []string{"full_name", "table_type"}, []*proto.Qual{
+		qual("catalog_name", "=", "main"),
+		qual("schema_name", "=", "sales"),
+	}, 0)
+
+	if got, want := columnStrings(rows, "full_name"), []string{"main.sales.customers", "main.sales.orders", "main.sales.orders_summary"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("full_name = %v, want %v", got, want)
+	}
+	if len(fake.requestsTo("/api/2.1/unity-catalog/catalogs")) != 0 || len(fake.requestsTo("/api/2.1/unity-catalog/schemas")) != 0 {
+		t.Errorf("expected no catalogs or schemas to be listed")
+	}
+}
+
+func TestListCatalogTablesAllSchemas(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_catalog_table", []string{"full_name", "owner"}, nil, 0)
+
+	if got, want := columnStrings(rows, "full_name"), []string{"main.default.events", "main.sales.customers", "main.sales.orders", "main.sales.orders_summary", "sandbox.scratch.tmp"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("full_name = %v, want %v", got, want)
+	}
+	if got := len(fake.requestsTo("/api/2.1/unity-catalog/schemas")); got != 2 {
+		t.Errorf("got %d requests to list schemas, want 2", got)
+	}
+	if len(fake.requestsTo("/api/2.1/unity-catalog/table-summaries")) != 0 {
+		t.Errorf("expected no table summaries to be listed by default")
+	}
+}
+
+func TestListCatalogTablesBySchemaName(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	// Only main has a sales schema, so the sandbox catalog is skipped
+	rows := p.query(t, "databricks_catalog_table", []string{"full_name"}, []*proto.Qual{
+		qual("schema_name", "=", "sales"),
+	}, 0)
+
+	if got, want := columnStrings(rows, "full_name"), []string{"main.sales.customers", "main.sales.orders", "main.sales.orders_summary"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("full_name = %v, want %v", got, want)
+	}
+	if len(fake.requestsTo("/api/2.1/unity-catalog/schemas")) != 0 {
+		t.Errorf("expected no schemas to be listed")
+	}
+}
+
+func TestListCatalogTablesWalkConcurrency(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	fake.latency = 100 * time.Millisecond
+	p := newTestPlugin(t, fake, "catalog_walk_concurrency = 1")
+
+	rows := p.query(t, "databricks_catalog_table", []string{"full_name"}, nil, 0)
+
+	if len(rows) != 5 {
+		t.Errorf("got %d tables, want 5", len(rows))
+	}
+	if got := fake.maxRequestsInFlight("/api/2.1/unity-catalog/tables"); got != 1 {
+		t.Errorf("got %d concurrent requests to list tables, want 1", got)
+	}
+}
+
+func TestListCatalogTablesSummaries(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake, "use_table_summaries = true")
+
+	rows := p.query(t, "databricks_catalog_table", []string{"full_name", "catalog_name", "schema_name", "name", "table_type"}, nil, 0)
+
+	if len(rows) != 5 {
+		t.Errorf("got %d tables, want 5", len(rows))
+	}
+	view := rowWith(t, rows, "full_name", "main.sales.orders_summary")
+	if view["catalog_name"] != "main" || view["schema_name"] != "sales" || view["name"] != "orders_summary" || view["table_type"] != "VIEW" {
+		t.Errorf("orders_summary = %v, want the main.sales view", view)
+	}
+
+	if len(fake.requestsTo("/api/2.1/unity-catalog/tables")) != 0 || len(fake.requestsTo("/api/2.1/unity-catalog/schemas")) != 0 {
+		t.Errorf("expected no schemas or tables to be listed")
+	}
+	// main has four tables, so takes two pages
+	if got := len(fake.requestsTo("/api/2.1/unity-catalog/table-summaries")); got != 3 {
+		t.Errorf("got %d requests to list table summaries, want 3", got)
+	}
+}
+
+func TestListCatalogTablesSummariesBySchemaName(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake, "use_table_summaries = true")
+
+	rows := p.query(t, "databricks_catalog_table", []string{"full_name"}, []*proto.Qual{
+		qual("catalog_name", "=", "main"),
+		qual("schema_name", "=", "default"),
+	}, 0)
+
+	// Both quals name a single schema, so its tables are listed directly
+	if got, want := columnStrings(rows, "full_name"), []string{"main.default.events"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("full_name = %v, want %v", got, want)
+	}
+	if len(fake.requestsTo("/api/2.1/unity-catalog/table-summaries")) != 0 {
+		t.Errorf("expected no table summaries to be listed")
+	}
+
+	rows = p.query(t, "databricks_catalog_table", []string{"full_name"}, []*proto.Qual{
+		qual("schema_name", "=", "sales"),
+	}, 0)
+
+	if len(rows) != 3 {
+		t.Errorf("got %d tables, want 3", len(rows))
+	}
+	for _, request := range fake.requestsTo("/api/2.1/unity-catalog/table-summaries") {
+		if got := request.Query.Get("schema_name_pattern"); got != "sales" {
+			t.Errorf("schema_name_pattern = %q, want sales", got)
+		}
+	}
+}
+
+func TestListCatalogTablesSummariesOtherColumns(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake, "use_table_summaries = true")
+
+	// The owner is not part of the summaries, so each schema is listed
+	rows := p.query(t, "databricks_catalog_table", []string{"full_name", "owner"}, nil, 0)
+
+	if len(rows) != 5 {
+		t.Errorf("got %d tables, want 5", len(rows))
+	}
+	if len(fake.requestsTo("/api/2.1/unity-catalog/table-summaries")) != 0 {
+		t.Errorf("expected no table summaries to be listed")
+	}
+}
+
+func TestListCatalogTablesRowFiltersAndColumnMasks(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_catalog_table", []string{"full_name", "row_filter_function", "has_column_masks"}, []*proto.Qual{
+		qual("catalog_name", "=", "main"),
+		qual("schema_name", "=", "sales"),
+	}, 0)
+
+	for _, test := range []struct {
+		fullName       string
+		rowFilter      interface{}
+		hasColumnMasks bool
+	}{
+		{"main.sales.customers", nil, true},
+		{"main.sales.orders", "main.sales.filter_region", false},
+		{"main.sales.orders_summary", nil, false},
+	} {
+		row := rowWith(t, rows, "full_name", test.fullName)
+		if row["row_filter_function"] != test.rowFilter || row["has_column_masks"] != test.hasColumnMasks {
+			t.Errorf("%s = %v, want row_filter_function %v and has_column_masks %v", test.fullName, row, test.rowFilter, test.hasColumnMasks)
+		}
+	}
+}
+
+func TestListCatalogTablesNotFound(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	for _, quals := range [][]*proto.Qual{
+		{qual("catalog_name", "=", "missing")},
+		{qual("catalog_name", "=", "missing"), qual("schema_name", "=", "default")},
+		{qual("catalog_name", "=", "main"), qual("schema_name", "=", "missing")},
+	} {
+		rows := p.query(t, "databricks_catalog_table", []string{"full_name"}, quals, 0)
+
+		if len(rows) != 0 {
+			t.Errorf("%v: got %d rows, want 0", quals, len(rows))
+		}
+	}
+}
@@ -0,0 +1,386 @@
+package databricks
+
+import (
+	"context"
+	"strings"
+
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/catalog"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableDatabricksCatalogTag(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "databricks_catalog_tag",
+		Description: "List the tags assigned to Unity Catalog catalogs, schemas, tables, columns and volumes.",
+		List: &plugin.ListConfig{
+			Hydrate:    listCatalogTags,
+			KeyColumns: plugin.OptionalColumns([]string{"securable_type", "full_name", "column_name"}),
+			Tags:       map[string]string{"service": "unity_catalog"},
+		},
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
+			{
+				Name:        "securable_type",
+				Description: "The type of the securable the tag is assigned to, one of catalog, schema, table, column or volume. Views are tables.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "full_name",
+				Description: "The full name of the securable the tag is assigned to, e.g. __catalog_name__.__schema_name__.__table_name__ for a table, or the table of a column.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "column_name",
+				Description: "The name of the column the tag is assigned to, for column tags.",
+				Transform:   transform.FromField("ColumnName").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "tag_name",
+				Description: "The name of the tag.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "tag_value",
+				Description: "The value of the tag, or null for a tag without a value.",
+				Transform:   transform.FromField("TagValue").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// Standard Steampipe columns
+			{
+				Name:        "title",
+				Description: "The title of the resource.",
+				Transform:   transform.FromField("TagName"),
+				Type:        proto.ColumnType_STRING,
+			},
+		}),
+	}
+}
+
+// catalogTagSecurableTypes are the securable types tags are listed for.
+var catalogTagSecurableTypes = []string{"catalog", "schema", "table", "column", "volume"}
+
+// catalogTagInfo is a tag assigned to a securable.
+type catalogTagInfo struct {
+	SecurableType string
+	FullName      string
+	ColumnName    string
+	TagName       string
+	TagValue      string
+}
+
+//// LIST FUNCTION
+
+func listCatalogTags(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	columnName := d.EqualsQualString("column_name")
+
+	types := map[string]bool{}
+	for _, value := range qualValues(d.Quals["securable_type"]) {
+		types[value.GetStringValue()] = true
+	}
+	wants := func(securableType string) bool {
+		return (len(types) == 0 || types[securableType]) && (columnName == "" || securableType == "column")
+	}
+
+	// Column tags are listed for each column of the tables walked, and views
+	// are tables in the tags API
+	walkWants := func(securableType string) bool {
+		switch securableType {
+		case "catalog", "schema", "volume":
+			return wants(securableType)
+		case "table", "view":
+			return wants("table") || wants("column")
+		}
+		return false
+	}
+
+	// Create client
+	client, err := getWorkspaceClient(ctx, d)
+	if err != nil {
+		logger.Error("databricks_catalog_tag.listCatalogTags", "connection_error", err)
+		return nil, err
+	}
+	apiClient, err := getWorkspaceAPIClient(ctx, d)
+	if err != nil {
+		logger.Error("databricks_catalog_tag.listCatalogTags", "connection_error", err)
+		return nil, err
+	}
+
+	ignoreError := func(err error) bool {
+		return shouldIgnoreErrors(catalogWalkIgnoreErrors)(ctx, d, nil, err)
+	}
+
+	// streamTags returns false once the limit has been hit
+	streamTags := func(securableType, fullName, columnName string) (bool, error) {
+		entityType, entityName := securableType+"s", fullName
+		if columnName != "" {
+			entityName = fullName + "." + columnName
+		}
+
+		assignments, err := listCatalogTagAssignments(ctx, apiClient, entityType, entityName)
+		if err != nil {
+			if ignoreError(err) {
+				return true, nil
+			}
+			return false, err
+		}
+
+		for _, assignment := range assignments {
+			d.StreamListItem(ctx, catalogTagInfo{
+				SecurableType: securableType,
+				FullName:      fullName,
+				ColumnName:    columnName,
+				TagName:       assignment.TagKey,
+				TagValue:      assignment.TagValue,
+			})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return false, nil
+			}
+		}
+		return true, nil
+	}
+
+	visit := func(securable catalogSecurable) (bool, error) {
+		if securable.Type != "table" && securable.Type != "view" {
+			return streamTags(securable.Type, securable.FullName, "")
+		}
+
+		if wants("table") {
+			if ok, err := streamTags("table", securable.FullName, ""); !ok {
+				return false, err
+			}
+		}
+		if wants("column") {
+			for _, column := range securable.Columns {
+				if columnName != "" && column != columnName {
+					continue
+				}
+				if ok, err := streamTags("column", securable.FullName, column); !ok {
+					return false, err
+				}
+			}
+		}
+		return true, nil
+	}
+
+	err = walkCatalogSecurables(ctx, client, walkWants, d.EqualsQualString("full_name"), ignoreError, visit)
+	if err != nil {
+		logger.Error("databricks_catalog_tag.listCatalogTags", "api_error", err)
+		return nil, err
+	}
+
+	return nil, nil
+}
+
+// walkCatalogSecurables calls visit for each securable of the wanted types
+// until it returns false. A full name narrows the walk to the securables it
+// could name: the metastore, catalogs and workspace level securables for a
+// name without dots, a schema for a name with one, and the tables, views,
+// volumes and functions of a single schema for a name with two. Listing
+// errors accepted by ignoreError skip the securables being listed.
+func walkCatalogSecurables(ctx context.Context, client *databricks.WorkspaceClient, wants func(string) bool, fullName string, ignoreError func(error) bool, visit func(catalogSecurable) (bool, error)) error {
+	var names []string
+	if fullName != "" {
+		names = strings.Split(fullName, ".")
+	}
+
+	switch len(names) {
+	case 2:
+		if wants("schema") {
+			_, err := visit(catalogSecurable{Type: "schema", FullName: fullName})
+			return err
+		}
+		return nil
+	case 3:
+		_, err := walkCatalogSchemaSecurables(ctx, client, wants, names[0], names[1], names[2], ignoreError, visit)
+		return err
+	case 0, 1:
+	default:
+		return nil
+	}
+
+	// Securables at the metastore level
+	if wants("metastore") {
+		assignment, err := client.Metastores.Current(ctx)
+		if err != nil {
+			if !ignoreError(err) {
+				return err
+			}
+		} else if fullName == "" || fullName == assignment.MetastoreId {
+			if ok, err := visit(catalogSecurable{Type: "metastore", FullName: assignment.MetastoreId}); !ok {
+				return err
+			}
+		}
+	}
+
+	if fullName != "" {
+		// The name is checked by the grants API, so nothing needs to be listed
+		for _, securableType := range []string{"catalog", "external_location", "storage_credential", "connection", "share"} {
+			if wants(securableType) {
+				if ok, err := visit(catalogSecurable{Type: securableType, FullName: fullName}); !ok {
+					return err
+				}
+			}
+		}
+		return nil
+	}
+
+	wantsSchemas := wants("schema") || wants("table") || wants("view") || wants("volume") || wants("function")
+	if wants("catalog") || wantsSchemas {
+		catalogs, err := client.Catalogs.ListAll(ctx)
+		if err != nil && !ignoreError(err) {
+			return err
+		}
+		for _, item := range catalogs {
+			if wants("catalog") {
+				if ok, err := visit(catalogSecurable{Type: "catalog", FullName: item.Name}); !ok {
+					return err
+				}
+			}
+			if !wantsSchemas {
+				continue
+			}
+
+			schemas, err := client.Schemas.ListAll(ctx, catalog.ListSchemasRequest{CatalogName: item.Name})
+			if err != nil {
+				if ignoreError(err) {
+					continue
+				}
+				return err
+			}
+			for _, schema := range schemas {
+				if wants("schema") {
+					if ok, err := visit(catalogSecurable{Type: "schema", FullName: schema.FullName}); !ok {
+						return err
+					}
+				}
+				if ok, err := walkCatalogSchemaSecurables(ctx, client, wants, item.Name, schema.Name, "", ignoreError, visit); !ok {
+					return err
+				}
+			}
+		}
+	}
+
+	if wants("external_location") {
+		locations, err := client.ExternalLocations.ListAll(ctx)
+		if err != nil && !ignoreError(err) {
+			return err
+		}
+		for _, item := range locations {
+			if ok, err := visit(catalogSecurable{Type: "external_location", FullName: item.Name}); !ok {
+				return err
+			}
+		}
+	}
+
+	if wants("storage_credential") {
+		credentials, err := client.StorageCredentials.ListAll(ctx)
+		if err != nil && !ignoreError(err) {
+			return err
+		}
+		for _, item := range credentials {
+			if ok, err := visit(catalogSecurable{Type: "storage_credential", FullName: item.Name}); !ok {
+				return err
+			}
+		}
+	}
+
+	if wants("connection") {
+		connections, err := client.Connections.ListAll(ctx)
+		if err != nil && !ignoreError(err) {
+			return err
+		}
+		for _, item := range connections {
+			if ok, err := visit(catalogSecurable{Type: "connection", FullName: item.Name}); !ok {
+				return err
+			}
+		}
+	}
+
+	if wants("share") {
+		shares, err := client.Shares.ListAll(ctx)
+		if err != nil && !ignoreError(err) {
+			return err
+		}
+		for _, item := range shares {
+			if ok, err := visit(catalogSecurable{Type: "share", FullName: item.Name}); !ok {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// walkCatalogSchemaSecurables visits the tables, views, volumes and functions
+// of a schema, or only those with the given name. It returns false if the
+// walk was stopped.
+func walkCatalogSchemaSecurables(ctx context.Context, client *databricks.WorkspaceClient, wants func(string) bool, catalogName, schemaName, name string, ignoreError func(error) bool, visit func(catalogSecurable) (bool, error)) (bool, error) {
+	matches := func(itemName string) bool {
+		return name == "" || itemName == name
+	}
+
+	if wants("table") || wants("view") {
+		tables, err := client.Tables.ListAll(ctx, catalog.ListTablesRequest{CatalogName: catalogName, SchemaName: schemaName})
+		if err != nil && !ignoreError(err) {
+			return false, err
+		}
+		for _, item := range tables {
+			securableType := "table"
+			if item.TableType == catalog.TableTypeView || item.TableType == catalog.TableTypeMaterializedView {
+				securableType = "view"
+			}
+			if !wants(securableType) || !matches(item.Name) {
+				continue
+			}
+			var columns []string
+			for _, column := range item.Columns {
+				columns = append(columns, column.Name)
+			}
+			if ok, err := visit(catalogSecurable{Type: securableType, FullName: item.FullName, Columns: columns}); !ok {
+				return false, err
+			}
+		}
+	}
+
+	if wants("volume") {
+		volumes, err := client.Volumes.ListAll(ctx, catalog.ListVolumesRequest{CatalogName: catalogName, SchemaName: schemaName})
+		if err != nil && !ignoreError(err) {
+			return false, err
+		}
+		for _, item := range volumes {
+			if !matches(item.Name) {
+				continue
+			}
+			if ok, err := visit(catalogSecurable{Type: "volume", FullName: item.FullName}); !ok {
+				return false, err
+			}
+		}
+	}
+
+	if wants("function") {
+		functions, err := client.Functions.ListAll(ctx, catalog.ListFunctionsRequest{CatalogName: catalogName, SchemaName: schemaName})
+		if err != nil && !ignoreError(err) {
+			return false, err
+		}
+		for _, item := range functions {
+			if !matches(item.Name) {
+				continue
+			}
+			if ok, err := visit(catalogSecurable{Type: "function", FullName: item.FullName}); !ok {
+				return false, err
+			}
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,139 @@
+package databricks
+
+import (
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+var catalogTagColumns = []string{"securable_type", "full_name", "column_name", "tag_name", "tag_value"}
+
+func TestListCatalogTags(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_catalog_tag", catalogTagColumns, nil, 0)
+
+	if len(rows) != 8 {
+		t.Errorf("got %d tags, want 8", len(rows))
+	}
+
+	var types []string
+	for _, row := range rows {
+		if securableType := row["securable_type"].(string); !slices.Contains(types, securableType) {
+			types = append(types, securableType)
+		}
+	}
+	slices.Sort(types)
+	if want := slices.Sorted(slices.Values(catalogTagSecurableTypes)); !reflect.DeepEqual(types, want) {
+		t.Errorf("securable_type = %v, want %v", types, want)
+	}
+
+	// A tag without a value has a null value
+	var pii map[string]interface{}
+	for _, row := range rows {
+		if row["securable_type"] == "table" && row["tag_name"] == "pii" {
+			pii = row
+		}
+	}
+	if pii == nil || pii["full_name"] != "main.sales.customers" || pii["column_name"] != nil || pii["tag_value"] != nil {
+		t.Errorf("pii tag of the table = %v, want main.sales.customers without a value", pii)
+	}
+
+	classification := rowWith(t, rows, "tag_name", "classification")
+	if classification["securable_type"] != "column" || classification["full_name"] != "main.sales.customers" || classification["column_name"] != "ssn" || classification["tag_value"] != "restricted" {
+		t.Errorf("classification = %v, want restricted on main.sales.customers.ssn", classification)
+	}
+}
+
+func TestListCatalogTagsBySecurableType(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_catalog_tag", catalogTagColumns, []*proto.Qual{
+		qual("securable_type", "=", []string{"catalog", "volume"}),
+	}, 0)
+
+	if got, want := columnStrings(rows, "tag_name"), []string{"environment", "retention"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("tag_name = %v, want %v", got, want)
+	}
+	if len(fake.requestsTo("/api/2.1/unity-catalog/tables")) != 0 {
+		t.Errorf("expected no tables to be listed")
+	}
+}
+
+func TestListCatalogTagsByFullName(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_catalog_tag", catalogTagColumns, []*proto.Qual{
+		qual("full_name", "=", "main.sales.customers"),
+		qual("securable_type", "=", "column"),
+	}, 0)
+
+	if len(rows) != 3 {
+		t.Errorf("got %d column tags, want 3", len(rows))
+	}
+	if got, want := columnStrings(rows, "column_name"), []string{"email", "ssn", "ssn"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("column_name = %v, want %v", got, want)
+	}
+	for _, request := range fake.requestsTo("/api/2.1/unity-catalog/tables") {
+		if request.Query.Get("catalog_name") != "main" || request.Query.Get("schema_name") != "sales" {
+			t.Errorf("got a request to list the tables of %s.%s, want only main.sales", request.Query.Get("catalog_name"), request.Query.Get("schema_name"))
+		}
+	}
+
+	rows = p.query(t, "databricks_catalog_tag", catalogTagColumns, []*proto.Qual{
+		qual("full_name", "=", "main"),
+	}, 0)
+
+	if len(rows) != 1 || rows[0]["securable_type"] != "catalog" || rows[0]["tag_value"] != "production" {
+		t.Errorf("got %v, want the environment tag of the main catalog", rows)
+	}
+	if len(fake.requestsTo("/api/2.1/unity-catalog/catalogs")) != 0 {
+		t.Errorf("expected no catalogs to be listed")
+	}
+}
+
+func TestListCatalogTagsByColumnName(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_catalog_tag", catalogTagColumns, []*proto.Qual{
+		qual("column_name", "=", "ssn"),
+	}, 0)
+
+	if got, want := columnStrings(rows, "tag_name"), []string{"classification", "pii"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("tag_name = %v, want %v", got, want)
+	}
+}
+
+func TestCatalogTagsColumns(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	for _, test := range []struct {
+		table    string
+		nameCol  string
+		name     string
+		wantTags map[string]interface{}
+	}{
+		{"databricks_catalog", "name", "main", map[string]interface{}{"environment": "production"}},
+		{"databricks_catalog_schema", "full_name", "main.sales", map[string]interface{}{"domain": "sales"}},
+		{"databricks_catalog_table", "full_name", "main.sales.customers", map[string]interface{}{"pii": "", "owner_team": "crm"}},
+		{"databricks_catalog_volume", "full_name", "main.sales.landing", map[string]interface{}{"retention": "30d"}},
+	} {
+		rows := p.query(t, test.table, []string{test.nameCol, "tags"}, nil, 0)
+
+		if got := rowWith(t, rows, test.nameCol, test.name)["tags"]; !reflect.DeepEqual(got, test.wantTags) {
+			t.Errorf("%s: tags of %s = %v, want %v", test.table, test.name, got, test.wantTags)
+		}
+	}
+
+	rows := p.query(t, "databricks_catalog", []string{"name", "tags"}, nil, 0)
+	if got := rowWith(t, rows, "name", "sandbox")["tags"]; !reflect.DeepEqual(got, map[string]interface{}{}) {
+		t.Errorf("tags of sandbox = %v, want none", got)
+	}
+}
@@ -0,0 +1,69 @@
+package databricks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestListCatalogs(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_catalog", []string{"name", "isolation_mode", "workspace_id"}, nil, 0)
+
+	if got, want := columnStrings(rows, "name"), []string{"main", "sandbox"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("name = %v, want %v", got, want)
+	}
+	if got := rowWith(t, rows, "name", "main")["workspace_id"]; got != int64(fakeWorkspaceId) {
+		t.Errorf("workspace_id = %v, want %d", got, fakeWorkspaceId)
+	}
+}
+
+func TestGetCatalog(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_catalog", []string{"name", "owner"}, []*proto.Qual{
+		qual("name", "=", "sandbox"),
+	}, 0)
+
+	if len(rows) != 1 || rows[0]["owner"] != "bob@example.com" {
+		t.Fatalf("got %v, want sandbox owned by bob@example.com", rows)
+	}
+	if len(fake.requestsTo("/api/2.1/unity-catalog/catalogs")) != 0 {
+		t.Errorf("expected the catalog to be fetched by name without listing")
+	}
+}
+
+func TestGetCatalogNotFound(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_catalog", []string{"name"}, []*proto.Qual{
+		qual("name", "=", "missing"),
+	}, 0)
+
+	if len(rows) != 0 {
+		t.Errorf("got %d rows, want 0", len(rows))
+	}
+}
+
+func TestGetCatalogIgnoreErrorCodes(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	fake.unavailable["/api/2.1/unity-catalog/catalogs/sandbox"] = 10
+	p := newTestPlugin(t, fake, "max_error_retry_attempts = 0", `ignore_error_codes = ["TEMPORARILY_UNAVAILABLE"]`)
+
+	// The error codes of the connection are ignored along with those of the
+	// table's get config
+	rows, err := p.tryQuery("databricks_catalog", []string{"name"}, []*proto.Qual{
+		qual("name", "=", "sandbox"),
+	}, 0)
+	if err != nil {
+		t.Fatalf("got error %v, want it ignored", err)
+	}
+	if len(rows) != 0 {
+		t.Errorf("got %d rows, want 0", len(rows))
+	}
+}
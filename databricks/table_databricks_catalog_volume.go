@@ -16,16 +16,31 @@ func tableDatabricksCatalogVolume(_ context.Context) *plugin.Table {
 		Name:        "databricks_catalog_volume",
 		Description: "Gets an array of the available volumes.",
 		List: &plugin.ListConfig{
-			Hydrate:           listCatalogVolumes,
-			ShouldIgnoreError: isNotFoundError([]string{"CATALOG_DOES_NOT_EXIST", "SCHEMA_DOES_NOT_EXIST"}),
-			KeyColumns:        plugin.AllColumns([]string{"catalog_name", "schema_name"}),
+			ParentHydrate: listCatalogWalkSchemas,
+			Hydrate:       listCatalogVolumes,
+			KeyColumns:    plugin.OptionalColumns([]string{"catalog_name", "schema_name"}),
+			ParentTags:    map[string]string{"service": "unity_catalog"},
+			Tags:          map[string]string{"service": "unity_catalog"},
 		},
 		Get: &plugin.GetConfig{
-			KeyColumns:        plugin.SingleColumn("full_name"),
-			ShouldIgnoreError: isNotFoundError([]string{"VOLUME_DOES_NOT_EXIST", "CATALOG_DOES_NOT_EXIST", "SCHEMA_DOES_NOT_EXIST"}),
-			Hydrate:           getCatalogVolume,
+			KeyColumns: plugin.SingleColumn("full_name"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"VOLUME_DOES_NOT_EXIST", "CATALOG_DOES_NOT_EXIST", "SCHEMA_DOES_NOT_EXIST"}),
+			},
+			Hydrate: getCatalogVolume,
+			Tags:    map[string]string{"service": "unity_catalog"},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getCatalogVolumeTags,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "unity_catalog"},
+			},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "volume_id",
 				Description: "The unique identifier of the volume.",
@@ -98,6 +113,13 @@ func tableDatabricksCatalogVolume(_ context.Context) *plugin.Table {
 				Description: "The type of the volume.",
 				Type:        proto.ColumnType_STRING,
 			},
+			{
+				Name:        "tags",
+				Description: "The tags assigned to the volume.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getCatalogVolumeTags,
+				Transform:   transform.FromValue(),
+			},
 
 			// Standard Steampipe columns
 			{
@@ -114,17 +136,19 @@ func tableDatabricksCatalogVolume(_ context.Context) *plugin.Table {
 
 func listCatalogVolumes(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
 	logger := plugin.Logger(ctx)
-	catalogName := d.EqualsQualString("catalog_name")
-	schemaName := d.EqualsQualString("schema_name")
+	parent := h.Item.(catalogWalkItem[catalog.SchemaInfo])
+	schema := parent.Value
 
-	// Return nil, if no input provided
-	if catalogName == "" || schemaName == "" {
-		return nil, nil
+	// Bound the number of schemas listed at the same time when walking the metastore
+	done, err := parent.wait(ctx)
+	if err != nil {
+		return nil, err
 	}
+	defer done()
 
 	request := catalog.ListVolumesRequest{
-		CatalogName: catalogName,
-		SchemaName:  schemaName,
+		CatalogName: schema.CatalogName,
+		SchemaName:  schema.Name,
 	}
 
 	// Create client
@@ -136,6 +160,9 @@ func listCatalogVolumes(ctx context.Context, d *plugin.QueryData, h *plugin.Hydr
 
 	volumes, err := client.Volumes.ListAll(ctx, request)
 	if err != nil {
+		if shouldIgnoreCatalogWalkError(ctx, d, h, err) {
+			return nil, nil
+		}
 		logger.Error("databricks_catalog_volume.listCatalogVolumes", "api_error", err)
 		return nil, err
 	}
@@ -178,5 +205,24 @@ func getCatalogVolume(ctx context.Context, d *plugin.QueryData, _ *plugin.Hydrat
 		logger.Error("databricks_catalog_volume.getCatalogVolume", "api_error", err)
 		return nil, err
 	}
-	return volume, nil
+	return *volume, nil
+}
+
+func getCatalogVolumeTags(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	name := h.Item.(catalog.VolumeInfo).FullName
+
+	// Create client
+	client, err := getWorkspaceAPIClient(ctx, d)
+	if err != nil {
+		logger.Error("databricks_catalog_volume.getCatalogVolumeTags", "connection_error", err)
+		return nil, err
+	}
+
+	assignments, err := listCatalogTagAssignments(ctx, client, "volumes", name)
+	if err != nil {
+		logger.Error("databricks_catalog_volume.getCatalogVolumeTags", "api_error", err)
+		return nil, err
+	}
+	return catalogTagsMap(assignments), nil
 }
@@ -0,0 +1,39 @@
+package databricks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestListCatalogVolumesAllSchemas(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_catalog_volume", []string{"full_name"}, nil, 0)
+
+	if got, want := columnStrings(rows, "full_name"), []string{"main.sales.landing"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("full_name = %v, want %v", got, want)
+	}
+	if got := len(fake.requestsTo("/api/2.1/unity-catalog/volumes")); got != 3 {
+		t.Errorf("got %d requests to list volumes, want 3", got)
+	}
+}
+
+func TestListCatalogVolumesNotFound(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	for _, quals := range [][]*proto.Qual{
+		{qual("catalog_name", "=", "missing")},
+		{qual("catalog_name", "=", "missing"), qual("schema_name", "=", "default")},
+		{qual("catalog_name", "=", "main"), qual("schema_name", "=", "missing")},
+	} {
+		rows := p.query(t, "databricks_catalog_volume", []string{"full_name"}, quals, 0)
+
+		if len(rows) != 0 {
+			t.Errorf("%v: got %d rows, want 0", quals, len(rows))
+		}
+	}
+}
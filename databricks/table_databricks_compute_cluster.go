@@ -2,10 +2,12 @@ package databricks
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/databricks/databricks-sdk-go/service/compute"
 	"github.com/databricks/databricks-sdk-go/service/iam"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/memoize"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 )
@@ -18,12 +20,31 @@ func tableDatabricksComputeCluster(_ context.Context) *plugin.Table {
 		Description: "Gets a list of clusters.",
 		List: &plugin.ListConfig{
 			Hydrate: listComputeClusters,
+			Tags:    map[string]string{"service": "compute"},
 		},
 		Get: &plugin.GetConfig{
 			KeyColumns: plugin.AnyColumn([]string{"cluster_id"}),
 			Hydrate:    getComputeCluster,
+			Tags:       map[string]string{"service": "compute"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getComputeClusterPermissions,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "compute"},
+			},
+			{
+				Func: getComputeClusterPolicyCompliance,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "compute"},
+			},
+		},
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "cluster_id",
 				Description: "Canonical identifier for the cluster.",
@@ -232,6 +253,20 @@ func tableDatabricksComputeCluster(_ context.Context) *plugin.Table {
 				Hydrate:     getComputeClusterPermissions,
 				Transform:   transform.FromValue(),
 			},
+			{
+				Name:        "policy_compliance",
+				Description: "The attributes of the cluster which violate the rules of its cluster policy, each with its path, rule type, value and a message. Null if the cluster has no policy.",
+				Type:        proto.ColumnType_JSON,
+				Hydrate:     getComputeClusterPolicyCompliance,
+				Transform:   transform.FromValue(),
+			},
+			{
+				Name:        "is_policy_compliant",
+				Description: "True if the cluster satisfies all the rules of its cluster policy. Null if the cluster has no policy.",
+				Type:        proto.ColumnType_BOOL,
+				Hydrate:     getComputeClusterPolicyCompliance,
+				Transform:   transform.FromValue().Transform(isEmptyViolations),
+			},
 			{
 				Name:        "spark_conf",
 				Description: "An object containing a set of optional, user-specified Spark configuration key-value pairs.",
@@ -350,3 +385,69 @@ func getComputeClusterPermissions(ctx context.Context, d *plugin.QueryData, h *p
 	}
 	return permission, nil
 }
+
+// getComputeClusterPolicyCompliance evaluates the attributes of the cluster
+// against the rules of its cluster policy and returns the violations.
+func getComputeClusterPolicyCompliance(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	cluster := h.Item.(compute.ClusterDetails)
+
+	// Return nil, if the cluster has no policy
+	if cluster.PolicyId == "" {
+		return nil, nil
+	}
+
+	rules, err := getComputeClusterPolicyRulesCached(ctx, d, &plugin.HydrateData{Item: cluster.PolicyId})
+	if err != nil {
+		logger.Error("databricks_compute_cluster.getComputeClusterPolicyCompliance", "api_error", err)
+		return nil, err
+	}
+	if rules == nil {
+		return nil, nil
+	}
+
+	violations, err := evaluateClusterPolicy(cluster, rules.([]computeClusterPolicyRuleInfo))
+	if err != nil {
+		logger.Error("databricks_compute_cluster.getComputeClusterPolicyCompliance", "evaluation_error", err)
+		return nil, err
+	}
+	return violations, nil
+}
+
+// Cached form of getComputeClusterPolicyRules, as clusters commonly share a
+// policy. The policy ID is passed as the hydrate item.
+var getComputeClusterPolicyRulesCached = plugin.HydrateFunc(getComputeClusterPolicyRulesUncached).Memoize(memoize.WithCacheKeyFunction(getComputeClusterPolicyRulesCacheKey))
+
+func getComputeClusterPolicyRulesCacheKey(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	key := fmt.Sprintf("getComputeClusterPolicyRules-%s-%s", d.EqualsQualString(matrixKeyWorkspaceHost), h.Item.(string))
+	return key, nil
+}
+
+func getComputeClusterPolicyRulesUncached(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	// Create client
+	client, err := getWorkspaceClient(ctx, d)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := client.ClusterPolicies.GetByPolicyId(ctx, h.Item.(string))
+	if err != nil {
+		// The policy was deleted after the cluster was created
+		if isNotFoundError([]string{"INVALID_PARAMETER_VALUE", "RESOURCE_DOES_NOT_EXIST"})(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return getClusterPolicyRules(ctx, client, *policy, map[string]string{})
+}
+
+//// TRANSFORM FUNCTIONS
+
+func isEmptyViolations(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	violations, ok := d.Value.([]clusterPolicyViolation)
+	if !ok {
+		return nil, nil
+	}
+	return len(violations) == 0, nil
+}
@@ -0,0 +1,211 @@
+package databricks
+
+import (
+	"context"
+
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Error codes returned for a cluster which does not exist, e.g. as it was
+// deleted after the clusters were listed
+var computeClusterNotFoundErrors = []string{"INVALID_PARAMETER_VALUE", "RESOURCE_DOES_NOT_EXIST"}
+
+//// TABLE DEFINITION
+
+func tableDatabricksComputeClusterEvent(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "databricks_compute_cluster_event",
+		Description: "List the events of each cluster, such as starts, resizes and terminations.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listComputeClusters,
+			Hydrate:       listComputeClusterEvents,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "cluster_id", Require: plugin.Optional},
+				{Name: "type", Require: plugin.Optional},
+				{Name: "timestamp", Require: plugin.Optional, Operators: []string{"=", ">", ">=", "<", "<="}},
+			},
+			ParentTags: map[string]string{"service": "compute"},
+			Tags:       map[string]string{"service": "compute"},
+		},
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
+			{
+				Name:        "cluster_id",
+				Description: "Canonical identifier for the cluster.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "cluster_name",
+				Description: "Cluster name requested by the user.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "timestamp",
+				Description: "The time when the event occurred.",
+				Transform:   transform.FromField("Timestamp").Transform(transform.UnixMsToTimestamp),
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "type",
+				Description: "The type of the event, e.g. CREATING, RESIZING, EDITED or TERMINATING.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "user",
+				Description: "The user that caused the event. Null if it was caused by Databricks.",
+				Transform:   transform.FromField("Details.User").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "cause",
+				Description: "The cause of a change in the target size of the cluster, e.g. AUTOSCALE, USER_REQUEST or AUTORECOVERY.",
+				Transform:   transform.FromField("Details.Cause").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "reason_code",
+				Description: "The reason the cluster terminated, or failed to acquire some nodes on resize, e.g. INACTIVITY or CLOUD_PROVIDER_LAUNCH_FAILURE.",
+				Transform:   transform.FromField("Details.Reason.Code").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "reason_type",
+				Description: "The type of the termination, e.g. SUCCESS, CLIENT_ERROR, SERVICE_FAULT or CLOUD_FAILURE.",
+				Transform:   transform.FromField("Details.Reason.Type").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "current_num_workers",
+				Description: "The number of worker nodes in the cluster when the event occurred.",
+				Transform:   transform.FromField("Details.CurrentNumWorkers"),
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "target_num_workers",
+				Description: "The number of worker nodes the cluster was resizing to.",
+				Transform:   transform.FromField("Details.TargetNumWorkers"),
+				Type:        proto.ColumnType_INT,
+			},
+
+			// JSON fields
+			{
+				Name:        "details",
+				Description: "The details of the event, such as the termination reason, the cluster size before and after a resize, and the attributes before and after an edit.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "data_plane_event_details",
+				Description: "The details of events reported by the cluster nodes, such as a node being excluded.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Standard Steampipe columns
+			{
+				Name:        "title",
+				Description: "The title of the resource.",
+				Transform:   transform.FromField("Type"),
+				Type:        proto.ColumnType_STRING,
+			},
+		}),
+	}
+}
+
+// computeClusterEventInfo is a cluster event along with the name of its
+// cluster.
+type computeClusterEventInfo struct {
+	compute.ClusterEvent
+	ClusterName string
+}
+
+//// LIST FUNCTION
+
+func listComputeClusterEvents(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	cluster := h.Item.(compute.ClusterDetails)
+
+	if d.EqualsQuals["cluster_id"] != nil && d.EqualsQualString("cluster_id") != cluster.ClusterId {
+		return nil, nil
+	}
+
+	// Create client
+	client, err := getWorkspaceClient(ctx, d)
+	if err != nil {
+		logger.Error("databricks_compute_cluster_event.listComputeClusterEvents", "connection_error", err)
+		return nil, err
+	}
+
+	// Limiting the results
+	maxLimit := int64(500)
+	if d.QueryContext.Limit != nil {
+		limit := *d.QueryContext.Limit
+		if limit < maxLimit {
+			maxLimit = limit
+		}
+	}
+
+	request := compute.GetEvents{
+		ClusterId: cluster.ClusterId,
+		Limit:     maxLimit,
+	}
+	for _, value := range qualValues(d.Quals["type"]) {
+		request.EventTypes = append(request.EventTypes, compute.EventType(value.GetStringValue()))
+	}
+	setGetEventsTimeRange(&request, d.Quals["timestamp"])
+
+	for {
+		response, err := client.Clusters.Impl().Events(ctx, request)
+		if err != nil {
+			// The cluster was deleted after it was listed. The ignore config of
+			// the list only applies to the parent hydrate.
+			if shouldIgnoreErrors(computeClusterNotFoundErrors)(ctx, d, h, err) {
+				return nil, nil
+			}
+			logger.Error("databricks_compute_cluster_event.listComputeClusterEvents", "api_error", err)
+			return nil, err
+		}
+
+		for _, event := range response.Events {
+			d.StreamListItem(ctx, computeClusterEventInfo{
+				ClusterEvent: event,
+				ClusterName:  cluster.ClusterName,
+			})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+
+		if response.NextPage == nil || len(response.Events) == 0 {
+			return nil, nil
+		}
+		request = *response.NextPage
+	}
+}
+
+// setGetEventsTimeRange sets the start and end time of the events request to
+// the tightest bounds of the timestamp quals. Both bounds are inclusive.
+func setGetEventsTimeRange(request *compute.GetEvents, timestampQuals *plugin.KeyColumnQuals) {
+	if timestampQuals == nil {
+		return
+	}
+	for _, q := range timestampQuals.Quals {
+		timestamp := q.Value.GetTimestampValue()
+		if timestamp == nil {
+			continue
+		}
+		ms := timestamp.AsTime().UnixMilli()
+		switch q.Operator {
+		case "=":
+			request.StartTime = max(request.StartTime, ms)
+			request.EndTime = minTime(request.EndTime, ms)
+		case ">", ">=":
+			request.StartTime = max(request.StartTime, ms)
+		case "<", "<=":
+			request.EndTime = minTime(request.EndTime, ms)
+		}
+	}
+}
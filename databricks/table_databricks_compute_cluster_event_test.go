@@ -0,0 +1,102 @@
+package databricks
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestListComputeClusterEvents(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_compute_cluster_event", []string{"cluster_id", "cluster_name", "type", "user", "reason_code", "target_num_workers"}, nil, 0)
+
+	if got, want := columnStrings(rows, "type"), []string{"CREATING", "RESIZING", "RUNNING", "STARTING", "TERMINATING", "UPSIZE_COMPLETED"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("type = %v, want %v", got, want)
+	}
+	terminating := rowWith(t, rows, "type", "TERMINATING")
+	if terminating["cluster_name"] != "ml-gpu" || terminating["reason_code"] != "INACTIVITY" {
+		t.Errorf("TERMINATING event = %v, want the ml-gpu inactivity termination", terminating)
+	}
+	if got := rowWith(t, rows, "type", "RESIZING")["target_num_workers"]; got != int64(6) {
+		t.Errorf("target_num_workers = %v, want 6", got)
+	}
+	if got := rowWith(t, rows, "type", "CREATING")["user"]; got != "alice@example.com" {
+		t.Errorf("user = %v, want alice@example.com", got)
+	}
+
+	// The events of the first cluster span two pages
+	if got := len(fake.requestsTo("/api/2.0/clusters/events")); got != 3 {
+		t.Errorf("got %d events requests, want 3", got)
+	}
+}
+
+func TestListComputeClusterEventsClusterId(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_compute_cluster_event", []string{"cluster_id", "type"}, []*proto.Qual{
+		qual("cluster_id", "=", "0101-000000-efgh5678"),
+	}, 0)
+
+	if got, want := columnStrings(rows, "type"), []string{"STARTING", "TERMINATING"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("type = %v, want %v", got, want)
+	}
+	for _, request := range fake.requestsTo("/api/2.0/clusters/events") {
+		if got := request.Body["cluster_id"]; got != "0101-000000-efgh5678" {
+			t.Errorf("cluster_id = %v, want 0101-000000-efgh5678", got)
+		}
+	}
+}
+
+// An IN list is split into a query per event type, each of which is pushed
+// down on its own.
+func TestListComputeClusterEventsPushdown(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_compute_cluster_event", []string{"cluster_id", "type", "timestamp"}, []*proto.Qual{
+		qual("type", "=", []string{"RESIZING", "UPSIZE_COMPLETED"}),
+		qual("timestamp", ">=", time.UnixMilli(1696150800000)),
+		qual("timestamp", "<", time.UnixMilli(1696237200000)),
+		qual("timestamp", "<=", time.UnixMilli(1696154400000)),
+	}, 0)
+
+	if got, want := columnStrings(rows, "type"), []string{"RESIZING"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("type = %v, want %v", got, want)
+	}
+	requests := fake.requestsTo("/api/2.0/clusters/events")
+	if len(requests) == 0 {
+		t.Fatal("got no events requests")
+	}
+	for _, request := range requests {
+		if got := request.Body["start_time"]; got != float64(1696150800000) {
+			t.Errorf("start_time = %v, want 1696150800000", got)
+		}
+		if got := request.Body["end_time"]; got != float64(1696154400000) {
+			t.Errorf("end_time = %v, want 1696154400000", got)
+		}
+		eventTypes, _ := request.Body["event_types"].([]interface{})
+		if len(eventTypes) != 1 || (eventTypes[0] != "RESIZING" && eventTypes[0] != "UPSIZE_COMPLETED") {
+			t.Errorf("event_types = %v, want a single type from the IN list", eventTypes)
+		}
+	}
+}
+
+func TestListComputeClusterEventsLimit(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	p.query(t, "databricks_compute_cluster_event", []string{"cluster_id", "type"}, []*proto.Qual{
+		qual("cluster_id", "=", "0101-000000-abcd1234"),
+	}, 1)
+
+	for _, request := range fake.requestsTo("/api/2.0/clusters/events") {
+		if got := request.Body["limit"]; got != float64(1) {
+			t.Errorf("limit = %v, want 1", got)
+		}
+	}
+}
@@ -0,0 +1,235 @@
+package databricks
+
+import (
+	"context"
+
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableDatabricksComputeClusterLibrary(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "databricks_compute_cluster_library",
+		Description: "List the libraries of each cluster along with their installation status.",
+		List: &plugin.ListConfig{
+			Hydrate:    listComputeClusterLibraries,
+			KeyColumns: plugin.OptionalColumns([]string{"cluster_id"}),
+			// A cluster_id of a cluster which does not exist is an invalid parameter
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"INVALID_PARAMETER_VALUE"}),
+			},
+			Tags: map[string]string{"service": "compute"},
+		},
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
+			{
+				Name:        "cluster_id",
+				Description: "Canonical identifier for the cluster.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "library_type",
+				Description: "The type of the library, one of pypi, maven, cran, jar, egg or whl.",
+				Transform:   transform.From(computeClusterLibraryType),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "package",
+				Description: "The name of a PyPI or CRAN package, optionally with a version specification, e.g. simplejson==3.8.0.",
+				Transform:   transform.From(computeClusterLibraryPackage),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "coordinates",
+				Description: "The Gradle-style coordinates of a Maven library, e.g. org.jsoup:jsoup:1.7.2.",
+				Transform:   transform.FromField("Library.Maven.Coordinates").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "repo",
+				Description: "The repository the package is installed from. Null if it is installed from the default repository, e.g. PyPI or Maven Central.",
+				Transform:   transform.From(computeClusterLibraryRepo),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "path",
+				Description: "The URI of a jar, egg or wheel library, e.g. dbfs:/mnt/libraries/library.jar.",
+				Transform:   transform.From(computeClusterLibraryPath),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "status",
+				Description: "The installation status of the library on the cluster, e.g. PENDING, INSTALLING, INSTALLED, FAILED or UNINSTALL_ON_RESTART.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "is_library_for_all_clusters",
+				Description: "Whether the library was set to be installed on all clusters via the libraries UI.",
+				Type:        proto.ColumnType_BOOL,
+			},
+
+			// JSON fields
+			{
+				Name:        "exclusions",
+				Description: "The dependencies to exclude when installing a Maven library.",
+				Transform:   transform.FromField("Library.Maven.Exclusions"),
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "messages",
+				Description: "The messages explaining the installation status, e.g. the reason an installation failed.",
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "library",
+				Description: "The full specification of the library.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Standard Steampipe columns
+			{
+				Name:        "title",
+				Description: "The title of the resource.",
+				Transform:   transform.From(computeClusterLibraryTitle),
+				Type:        proto.ColumnType_STRING,
+			},
+		}),
+	}
+}
+
+// computeClusterLibraryInfo is the status of a library on a cluster.
+type computeClusterLibraryInfo struct {
+	compute.LibraryFullStatus
+	ClusterId string
+}
+
+//// LIST FUNCTION
+
+func listComputeClusterLibraries(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+
+	// Create client
+	client, err := getWorkspaceClient(ctx, d)
+	if err != nil {
+		logger.Error("databricks_compute_cluster_library.listComputeClusterLibraries", "connection_error", err)
+		return nil, err
+	}
+
+	var statuses []compute.ClusterLibraryStatuses
+	if d.EqualsQuals["cluster_id"] != nil {
+		response, err := client.Libraries.ClusterStatusByClusterId(ctx, d.EqualsQualString("cluster_id"))
+		if err != nil {
+			logger.Error("databricks_compute_cluster_library.listComputeClusterLibraries", "api_error", err)
+			return nil, err
+		}
+		statuses = append(statuses, *response)
+	} else {
+		response, err := client.Libraries.AllClusterStatuses(ctx)
+		if err != nil {
+			logger.Error("databricks_compute_cluster_library.listComputeClusterLibraries", "api_error", err)
+			return nil, err
+		}
+		statuses = response.Statuses
+	}
+
+	for _, cluster := range statuses {
+		for _, status := range cluster.LibraryStatuses {
+			d.StreamListItem(ctx, computeClusterLibraryInfo{
+				LibraryFullStatus: status,
+				ClusterId:         cluster.ClusterId,
+			})
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func computeClusterLibraryType(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	library := d.HydrateItem.(computeClusterLibraryInfo).Library
+	switch {
+	case library == nil:
+		return nil, nil
+	case library.Pypi != nil:
+		return "pypi", nil
+	case library.Maven != nil:
+		return "maven", nil
+	case library.Cran != nil:
+		return "cran", nil
+	case library.Jar != "":
+		return "jar", nil
+	case library.Egg != "":
+		return "egg", nil
+	case library.Whl != "":
+		return "whl", nil
+	}
+	return nil, nil
+}
+
+func computeClusterLibraryPackage(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	library := d.HydrateItem.(computeClusterLibraryInfo).Library
+	switch {
+	case library == nil:
+		return nil, nil
+	case library.Pypi != nil:
+		return library.Pypi.Package, nil
+	case library.Cran != nil:
+		return library.Cran.Package, nil
+	}
+	return nil, nil
+}
+
+func computeClusterLibraryRepo(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	library := d.HydrateItem.(computeClusterLibraryInfo).Library
+	var repo string
+	switch {
+	case library == nil:
+		return nil, nil
+	case library.Pypi != nil:
+		repo = library.Pypi.Repo
+	case library.Maven != nil:
+		repo = library.Maven.Repo
+	case library.Cran != nil:
+		repo = library.Cran.Repo
+	}
+	if repo == "" {
+		return nil, nil
+	}
+	return repo, nil
+}
+
+func computeClusterLibraryPath(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	library := d.HydrateItem.(computeClusterLibraryInfo).Library
+	switch {
+	case library == nil:
+		return nil, nil
+	case library.Jar != "":
+		return library.Jar, nil
+	case library.Egg != "":
+		return library.Egg, nil
+	case library.Whl != "":
+		return library.Whl, nil
+	}
+	return nil, nil
+}
+
+func computeClusterLibraryTitle(ctx context.Context, d *transform.TransformData) (interface{}, error) {
+	library := d.HydrateItem.(computeClusterLibraryInfo).Library
+	if library != nil && library.Maven != nil {
+		return library.Maven.Coordinates, nil
+	}
+	if title, _ := computeClusterLibraryPackage(ctx, d); title != nil {
+		return title, nil
+	}
+	return computeClusterLibraryPath(ctx, d)
+}
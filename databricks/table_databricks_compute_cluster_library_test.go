@@ -0,0 +1,73 @@
+package databricks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestListComputeClusterLibraries(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_compute_cluster_library", []string{"cluster_id", "library_type", "package", "coordinates", "repo", "path", "status", "messages", "is_library_for_all_clusters", "title"}, nil, 0)
+
+	if got, want := columnStrings(rows, "library_type"), []string{"maven", "maven", "pypi", "pypi", "whl"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("library_type = %v, want %v", got, want)
+	}
+
+	failed := rowWith(t, rows, "status", "FAILED")
+	if failed["package"] != "internal-utils" || failed["repo"] != "https://pypi.example.com/simple" {
+		t.Errorf("failed library = %v, want internal-utils from the private index", failed)
+	}
+	if messages, ok := failed["messages"].([]interface{}); !ok || len(messages) != 1 {
+		t.Errorf("messages = %v, want the installation error", failed["messages"])
+	}
+
+	pandas := rowWith(t, rows, "package", "pandas==2.1.1")
+	if pandas["repo"] != nil || pandas["coordinates"] != nil || pandas["is_library_for_all_clusters"] != false {
+		t.Errorf("pandas library = %v, want no repo or coordinates", pandas)
+	}
+
+	jsoup := rowWith(t, rows, "coordinates", "org.jsoup:jsoup:1.7.2")
+	if jsoup["is_library_for_all_clusters"] != true || jsoup["title"] != "org.jsoup:jsoup:1.7.2" {
+		t.Errorf("maven library = %v, want a library for all clusters", jsoup)
+	}
+
+	if got := rowWith(t, rows, "library_type", "whl")["path"]; got != "dbfs:/FileStore/wheels/model-0.1-py3-none-any.whl" {
+		t.Errorf("path = %v, want the wheel URI", got)
+	}
+	if got := len(fake.requestsTo("/api/2.0/libraries/cluster-status")); got != 0 {
+		t.Errorf("got %d cluster status requests, want 0", got)
+	}
+}
+
+func TestListComputeClusterLibrariesClusterId(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_compute_cluster_library", []string{"cluster_id", "status"}, []*proto.Qual{
+		qual("cluster_id", "=", "0101-000000-efgh5678"),
+	}, 0)
+
+	if got, want := columnStrings(rows, "cluster_id"), []string{"0101-000000-efgh5678", "0101-000000-efgh5678"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("cluster_id = %v, want %v", got, want)
+	}
+	if got := len(fake.requestsTo("/api/2.0/libraries/all-cluster-statuses")); got != 0 {
+		t.Errorf("got %d all cluster statuses requests, want 0", got)
+	}
+}
+
+func TestListComputeClusterLibrariesClusterNotFound(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_compute_cluster_library", []string{"cluster_id"}, []*proto.Qual{
+		qual("cluster_id", "=", "0101-000000-missing"),
+	}, 0)
+
+	if len(rows) != 0 {
+		t.Errorf("got %d rows, want 0", len(rows))
+	}
+}
@@ -16,8 +16,10 @@ func tableDatabricksComputeClusterNodeType(_ context.Context) *plugin.Table {
 		Description: "Returns a list of supported Spark node types.",
 		List: &plugin.ListConfig{
 			Hydrate: listComputeClusterNodeTypes,
+			Tags:    map[string]string{"service": "compute"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "node_type_id",
 				Description: "Unique identifier for this node type.",
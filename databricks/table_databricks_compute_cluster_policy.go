@@ -17,12 +17,15 @@ func tableDatabricksComputeClusterPolicy(_ context.Context) *plugin.Table {
 		Description: "Gets an array of cluster policies.",
 		List: &plugin.ListConfig{
 			Hydrate: listComputeClusterPolicies,
+			Tags:    map[string]string{"service": "compute"},
 		},
 		Get: &plugin.GetConfig{
 			KeyColumns: plugin.SingleColumn("policy_id"),
 			Hydrate:    getComputeClusterPolicy,
+			Tags:       map[string]string{"service": "compute"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "policy_id",
 				Description: "Canonical unique identifier for the Cluster Policy.",
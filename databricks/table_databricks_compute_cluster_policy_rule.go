@@ -0,0 +1,193 @@
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableDatabricksComputeClusterPolicyRule(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "databricks_compute_cluster_policy_rule",
+		Description: "List the rules of each cluster policy, one per cluster attribute path.",
+		List: &plugin.ListConfig{
+			Hydrate:    listComputeClusterPolicyRules,
+			KeyColumns: plugin.OptionalColumns([]string{"policy_id"}),
+			// A policy_id of a policy which does not exist is an invalid parameter
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"INVALID_PARAMETER_VALUE"}),
+			},
+			Tags: map[string]string{"service": "compute"},
+		},
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
+			{
+				Name:        "policy_id",
+				Description: "Canonical unique identifier for the cluster policy.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "policy_name",
+				Description: "The name of the cluster policy.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "policy_family_id",
+				Description: "The ID of the policy family the policy is based on, if any.",
+				Transform:   transform.FromField("PolicyFamilyId").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "path",
+				Description: "The path of the cluster attribute the rule applies to, e.g. autotermination_minutes or spark_conf.spark.databricks.cluster.profile.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source",
+				Description: "Where the rule is defined: policy for the definition of the policy, policy_family for the definition of its policy family, or override for the policy family definition overrides of the policy.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "rule_type",
+				Description: "The type of the rule, one of fixed, forbidden, allowlist, blocklist, regex, range or unlimited.",
+				Transform:   transform.FromField("Rule.Type").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "value",
+				Description: "The value of a fixed rule.",
+				Transform:   transform.FromField("Rule.Value"),
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "values",
+				Description: "The values of an allowlist or blocklist rule.",
+				Transform:   transform.FromField("Rule.Values"),
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "pattern",
+				Description: "The regular expression values must match for a regex rule.",
+				Transform:   transform.FromField("Rule.Pattern").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "min_value",
+				Description: "The minimum value of a range rule.",
+				Transform:   transform.FromField("Rule.MinValue"),
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "max_value",
+				Description: "The maximum value of a range rule.",
+				Transform:   transform.FromField("Rule.MaxValue"),
+				Type:        proto.ColumnType_DOUBLE,
+			},
+			{
+				Name:        "default_value",
+				Description: "The value used when the attribute is not set by the user.",
+				Transform:   transform.FromField("Rule.DefaultValue"),
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "is_optional",
+				Description: "Whether the attribute may be left unset.",
+				Transform:   transform.FromField("Rule.IsOptional"),
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "hidden",
+				Description: "Whether the attribute is hidden from the cluster creation form.",
+				Transform:   transform.FromField("Rule.Hidden"),
+				Type:        proto.ColumnType_BOOL,
+			},
+
+			// JSON fields
+			{
+				Name:        "definition",
+				Description: "The rule as it appears in the policy definition.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Standard Steampipe columns
+			{
+				Name:        "title",
+				Description: "The title of the resource.",
+				Transform:   transform.FromField("Path"),
+				Type:        proto.ColumnType_STRING,
+			},
+		}),
+	}
+}
+
+// computeClusterPolicyRuleInfo is the rule of a cluster policy for a single
+// attribute path.
+type computeClusterPolicyRuleInfo struct {
+	PolicyId       string
+	PolicyName     string
+	PolicyFamilyId string
+	Path           string
+	Source         string
+	Rule           clusterPolicyRule
+	Definition     json.RawMessage
+}
+
+//// LIST FUNCTION
+
+func listComputeClusterPolicyRules(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+
+	// Create client
+	client, err := getWorkspaceClient(ctx, d)
+	if err != nil {
+		logger.Error("databricks_compute_cluster_policy_rule.listComputeClusterPolicyRules", "connection_error", err)
+		return nil, err
+	}
+
+	var policies []compute.Policy
+	if d.EqualsQuals["policy_id"] != nil {
+		policy, err := client.ClusterPolicies.GetByPolicyId(ctx, d.EqualsQualString("policy_id"))
+		if err != nil {
+			logger.Error("databricks_compute_cluster_policy_rule.listComputeClusterPolicyRules", "api_error", err)
+			return nil, err
+		}
+		policies = append(policies, *policy)
+	} else {
+		policies, err = client.ClusterPolicies.ListAll(ctx, compute.ListClusterPoliciesRequest{})
+		if err != nil {
+			logger.Error("databricks_compute_cluster_policy_rule.listComputeClusterPolicyRules", "api_error", err)
+			return nil, err
+		}
+	}
+
+	// Policies commonly share a family, so each family is only fetched once
+	familyDefinitions := map[string]string{}
+
+	for _, policy := range policies {
+		rules, err := getClusterPolicyRules(ctx, client, policy, familyDefinitions)
+		if err != nil {
+			logger.Error("databricks_compute_cluster_policy_rule.listComputeClusterPolicyRules", "api_error", err, "policy_id", policy.PolicyId)
+			return nil, err
+		}
+
+		for _, rule := range rules {
+			rule.PolicyId = policy.PolicyId
+			rule.PolicyName = policy.Name
+			rule.PolicyFamilyId = policy.PolicyFamilyId
+			d.StreamListItem(ctx, rule)
+
+			// Context can be cancelled due to manual cancellation or the limit has been hit
+			if d.RowsRemaining(ctx) == 0 {
+				return nil, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,93 @@
+package databricks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestListComputeClusterPolicyRules(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_compute_cluster_policy_rule", []string{"policy_id", "path", "source", "rule_type", "value", "values", "pattern", "min_value", "max_value", "default_value", "is_optional", "hidden"}, []*proto.Qual{
+		qual("policy_id", "=", "E0631F5C0D000001"),
+	}, 0)
+
+	if got, want := columnStrings(rows, "path"), []string{"autotermination_minutes", "custom_tags.team", "node_type_id", "num_workers", "spark_version"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("path = %v, want %v", got, want)
+	}
+
+	tests := []struct {
+		path   string
+		column string
+		want   interface{}
+	}{
+		{"autotermination_minutes", "rule_type", "fixed"},
+		{"autotermination_minutes", "value", float64(30)},
+		{"autotermination_minutes", "hidden", true},
+		{"node_type_id", "values", []interface{}{"i3.xlarge", "i3.2xlarge"}},
+		{"node_type_id", "default_value", "i3.xlarge"},
+		{"num_workers", "min_value", float64(1)},
+		{"num_workers", "max_value", float64(10)},
+		{"num_workers", "is_optional", true},
+		{"spark_version", "pattern", `1[3-9]\.[0-9]+\.x-scala.*`},
+		{"spark_version", "value", nil},
+		{"custom_tags.team", "rule_type", "unlimited"},
+		{"custom_tags.team", "source", "policy"},
+	}
+	for _, tt := range tests {
+		if got := rowWith(t, rows, "path", tt.path)[tt.column]; !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s %s = %#v, want %#v", tt.path, tt.column, got, tt.want)
+		}
+	}
+}
+
+func TestListComputeClusterPolicyRulesFamilyOverrides(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_compute_cluster_policy_rule", []string{"policy_id", "policy_family_id", "path", "source", "rule_type", "max_value", "default_value"}, nil, 0)
+
+	var familyRows []map[string]interface{}
+	for _, row := range rows {
+		if row["policy_id"] == "E0631F5C0D000002" {
+			familyRows = append(familyRows, row)
+		}
+	}
+	if got, want := columnStrings(familyRows, "path"), []string{"autotermination_minutes", "data_security_mode", "instance_pool_id", "num_workers"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("path = %v, want %v", got, want)
+	}
+
+	autotermination := rowWith(t, familyRows, "path", "autotermination_minutes")
+	if autotermination["source"] != "override" || autotermination["max_value"] != float64(60) || autotermination["default_value"] != float64(30) {
+		t.Errorf("autotermination_minutes = %v, want the override rule", autotermination)
+	}
+	if got := rowWith(t, familyRows, "path", "num_workers")["source"]; got != "policy_family" {
+		t.Errorf("num_workers source = %v, want policy_family", got)
+	}
+	if got := rowWith(t, familyRows, "path", "instance_pool_id")["rule_type"]; got != "forbidden" {
+		t.Errorf("instance_pool_id rule_type = %v, want forbidden", got)
+	}
+	if got := rowWith(t, familyRows, "path", "data_security_mode")["policy_family_id"]; got != "personal-vm" {
+		t.Errorf("policy_family_id = %v, want personal-vm", got)
+	}
+
+	if got := len(fake.requestsTo("/api/2.0/policy-families/personal-vm")); got != 1 {
+		t.Errorf("got %d policy family requests, want 1", got)
+	}
+}
+
+func TestListComputeClusterPolicyRulesNotFound(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_compute_cluster_policy_rule", []string{"path"}, []*proto.Qual{
+		qual("policy_id", "=", "E0631F5C0DMISSING"),
+	}, 0)
+
+	if len(rows) != 0 {
+		t.Errorf("got %d rows, want 0", len(rows))
+	}
+}
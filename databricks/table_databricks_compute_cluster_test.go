@@ -0,0 +1,103 @@
+package databricks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestListComputeClusters(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_compute_cluster", []string{"cluster_id", "cluster_name", "state"}, nil, 0)
+
+	if got, want := columnStrings(rows, "cluster_name"), []string{"ml-gpu", "shared-autoscaling"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("cluster_name = %v, want %v", got, want)
+	}
+	if got := rowWith(t, rows, "cluster_name", "ml-gpu")["state"]; got != "TERMINATED" {
+		t.Errorf("state = %v, want TERMINATED", got)
+	}
+}
+
+func TestGetComputeClusterNotFound(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_compute_cluster", []string{"cluster_id"}, []*proto.Qual{
+		qual("cluster_id", "=", "0101-000000-missing"),
+	}, 0)
+
+	if len(rows) != 0 {
+		t.Errorf("got %d rows, want 0", len(rows))
+	}
+}
+
+func TestComputeClusterPermissions(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	fake.permissionDenied["0101-000000-efgh5678"] = true
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_compute_cluster", []string{"cluster_id", "cluster_permissions"}, nil, 0)
+
+	permissions, ok := rowWith(t, rows, "cluster_id", "0101-000000-abcd1234")["cluster_permissions"].(map[string]interface{})
+	if !ok || permissions["object_id"] != "/clusters/0101-000000-abcd1234" {
+		t.Errorf("cluster_permissions = %v, want the cluster permissions", permissions)
+	}
+	if got := rowWith(t, rows, "cluster_id", "0101-000000-efgh5678")["cluster_permissions"]; got != nil {
+		t.Errorf("cluster_permissions = %v, want null", got)
+	}
+}
+
+func TestComputeClusterPolicyCompliance(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_compute_cluster", []string{"cluster_id", "policy_compliance", "is_policy_compliant"}, nil, 0)
+
+	shared := rowWith(t, rows, "cluster_id", "0101-000000-abcd1234")
+	if shared["is_policy_compliant"] != false {
+		t.Errorf("is_policy_compliant = %v, want false", shared["is_policy_compliant"])
+	}
+	violations, _ := shared["policy_compliance"].([]interface{})
+	if len(violations) != 1 {
+		t.Fatalf("policy_compliance = %v, want a single violation", shared["policy_compliance"])
+	}
+	if violation := violations[0].(map[string]interface{}); violation["path"] != "autotermination_minutes" || violation["rule_type"] != "fixed" || violation["value"] != float64(60) {
+		t.Errorf("violation = %v, want autotermination_minutes not fixed to 30", violation)
+	}
+
+	// The policy is based on a family, whose rules are merged with the overrides
+	gpu := rowWith(t, rows, "cluster_id", "0101-000000-efgh5678")
+	if gpu["is_policy_compliant"] != true {
+		t.Errorf("is_policy_compliant = %v, want true", gpu["is_policy_compliant"])
+	}
+	if violations, ok := gpu["policy_compliance"].([]interface{}); !ok || len(violations) != 0 {
+		t.Errorf("policy_compliance = %v, want no violations", gpu["policy_compliance"])
+	}
+
+	for _, path := range []string{"/api/2.0/policies/clusters/get", "/api/2.0/policy-families/personal-vm"} {
+		if got := len(fake.requestsTo(path)); got > 2 {
+			t.Errorf("got %d requests to %s, want at most one per policy", got, path)
+		}
+	}
+}
+
+func TestComputeClusterPolicyComplianceDeletedPolicy(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	fake.clusters = append(fake.clusters, map[string]interface{}{
+		"cluster_id":   "0101-000000-ijkl9012",
+		"cluster_name": "orphaned",
+		"policy_id":    "E0631F5C0DDELETED",
+	})
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_compute_cluster", []string{"cluster_id", "policy_compliance", "is_policy_compliant"}, nil, 0)
+
+	// The policy was deleted after the cluster was created, so its compliance is unknown
+	orphaned := rowWith(t, rows, "cluster_id", "0101-000000-ijkl9012")
+	if orphaned["policy_compliance"] != nil || orphaned["is_policy_compliant"] != nil {
+		t.Errorf("got policy_compliance = %v, is_policy_compliant = %v, want both null", orphaned["policy_compliance"], orphaned["is_policy_compliant"])
+	}
+}
@@ -16,12 +16,19 @@ func tableDatabricksComputeGlobalInitScript(_ context.Context) *plugin.Table {
 		Description: "Gets a list of all global init scripts for this workspace.",
 		List: &plugin.ListConfig{
 			Hydrate: listComputeGlobalInitScripts,
+			// Only workspace admins can list these, other users get an empty table
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+			},
+			Tags: map[string]string{"service": "compute"},
 		},
 		Get: &plugin.GetConfig{
 			KeyColumns: plugin.SingleColumn("script_id"),
 			Hydrate:    getComputeGlobalInitScript,
+			Tags:       map[string]string{"service": "compute"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "script_id",
 				Description: "The global init script ID.",
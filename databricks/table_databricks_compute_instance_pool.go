@@ -18,12 +18,24 @@ func tableDatabricksComputeInstancePool(_ context.Context) *plugin.Table {
 		Description: "Gets a list of instance pools with their statistics.",
 		List: &plugin.ListConfig{
 			Hydrate: listComputeInstancePools,
+			Tags:    map[string]string{"service": "compute"},
 		},
 		Get: &plugin.GetConfig{
 			KeyColumns: plugin.SingleColumn("instance_pool_id"),
 			Hydrate:    getComputeInstancePool,
+			Tags:       map[string]string{"service": "compute"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getComputeInstancePoolPermissions,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "compute"},
+			},
+		},
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "instance_pool_id",
 				Description: "Canonical unique identifier for the pool.",
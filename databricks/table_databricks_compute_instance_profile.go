@@ -16,8 +16,10 @@ func tableDatabricksComputeInstanceProfile(_ context.Context) *plugin.Table {
 		Description: "List the instance profiles that the calling user can use to launch a cluster.",
 		List: &plugin.ListConfig{
 			Hydrate: listComputeInstanceProfiles,
+			Tags:    map[string]string{"service": "compute"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "instance_profile_arn",
 				Description: "The AWS ARN of the instance profile to register with Databricks.",
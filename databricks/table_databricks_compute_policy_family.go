@@ -17,12 +17,15 @@ func tableDatabricksComputePolicyFamily(_ context.Context) *plugin.Table {
 		Description: "Retrieve a list of policy families.",
 		List: &plugin.ListConfig{
 			Hydrate: listComputePolicyFamilies,
+			Tags:    map[string]string{"service": "compute"},
 		},
 		Get: &plugin.GetConfig{
 			KeyColumns: plugin.SingleColumn("policy_family_id"),
 			Hydrate:    getComputePolicyFamily,
+			Tags:       map[string]string{"service": "compute"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "policy_family_id",
 				Description: "ID of the policy family.",
@@ -0,0 +1,119 @@
+package databricks
+
+import (
+	"context"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableDatabricksConnectionInfo(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "databricks_connection_info",
+		Description: "Shows how the account and workspace credentials of the connection are resolved.",
+		List: &plugin.ListConfig{
+			Hydrate: listConnectionInfo,
+		},
+		Columns: databricksAccountColumns([]*plugin.Column{
+			{
+				Name:        "client_type",
+				Description: "The type of client the credentials are resolved for, either account or workspace.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "auth_type",
+				Description: "The resolved authentication type, e.g. pat, oauth-m2m or azure-client-secret.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "auth_type_source",
+				Description: "The source the authentication type was resolved from: connection_config, environment or profile.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "host",
+				Description: "The resolved host of the client.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "host_source",
+				Description: "The source the host was resolved from.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "profile",
+				Description: "The profile used to resolve the remaining settings, if any.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "error",
+				Description: "The error returned while resolving the credentials, if any.",
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// JSON fields
+			{
+				Name:        "settings",
+				Description: "The resolved settings along with their source. Sensitive values are masked.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Standard Steampipe columns
+			{
+				Name:        "title",
+				Description: "The title of the resource.",
+				Type:        proto.ColumnType_STRING,
+				Transform:   transform.FromField("ClientType"),
+			},
+		}),
+	}
+}
+
+type connectionInfo struct {
+	ClientType     string
+	AuthType       string
+	AuthTypeSource string
+	Host           string
+	HostSource     string
+	Profile        string
+	Error          string
+	Settings       []resolvedSetting
+}
+
+//// LIST FUNCTION
+
+func listConnectionInfo(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	config := GetConfig(d.Connection)
+
+	for _, scope := range []clientScope{clientScopeAccount, clientScopeWorkspace} {
+		info := connectionInfo{ClientType: string(scope)}
+
+		// Resolution errors are returned as a column rather than failing the
+		// query, so that misconfigured connections can be diagnosed
+		creds, err := resolveCredentials(config, scope, "")
+		if err != nil {
+			logger.Warn("databricks_connection_info.listConnectionInfo", "client_type", scope, "resolve_error", err)
+			info.Error = err.Error()
+		} else {
+			info.AuthType = creds.AuthType
+			info.AuthTypeSource = creds.AuthTypeSource
+			info.Host = creds.Config.Host
+			info.HostSource = creds.settingSource("host")
+			info.Profile = creds.Config.Profile
+			info.Settings = creds.Settings
+		}
+
+		d.StreamListItem(ctx, info)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
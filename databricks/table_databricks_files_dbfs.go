@@ -16,11 +16,21 @@ func tableDatabricksFilesDbfs(_ context.Context) *plugin.Table {
 		Name:        "databricks_files_dbfs",
 		Description: "List the contents of a directory, or details of the file.",
 		List: &plugin.ListConfig{
-			Hydrate:           listFilesDbfs,
-			ShouldIgnoreError: isNotFoundError([]string{"RESOURCE_DOES_NOT_EXIST", "INVALID_PARAMETER_VALUE"}),
-			KeyColumns:        plugin.AnyColumn([]string{"path", "path_prefix"}),
+			Hydrate: listFilesDbfs,
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"RESOURCE_DOES_NOT_EXIST", "INVALID_PARAMETER_VALUE"}),
+			},
+			KeyColumns: plugin.AnyColumn([]string{"path", "path_prefix"}),
+			Tags:       map[string]string{"service": "workspace"},
 		},
-		Columns: []*plugin.Column{
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getFilesDbfsContent,
+				Tags: map[string]string{"service": "workspace"},
+			},
+		},
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "path",
 				Description: "The path of the file or directory.",
@@ -65,7 +75,7 @@ func tableDatabricksFilesDbfs(_ context.Context) *plugin.Table {
 				Type:        proto.ColumnType_STRING,
 				Transform:   transform.FromField("Path"),
 			},
-		},
+		}),
 	}
 }
 
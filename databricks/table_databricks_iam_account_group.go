@@ -16,19 +16,17 @@ func tableDatabricksIAMAccountGroup(_ context.Context) *plugin.Table {
 		Name:        "databricks_iam_account_group",
 		Description: "List group details associated with a Databricks account.",
 		List: &plugin.ListConfig{
-			KeyColumns: []*plugin.KeyColumn{
-				{
-					Name:      "display_name",
-					Require:   plugin.Optional,
-					Operators: []string{"=", "<>"},
-				},
-			},
-			Hydrate: listIAMAccountGroups,
+			KeyColumns: scimKeyColumns(scimGroupFilterAttributes),
+			Hydrate:    listIAMAccountGroups,
+			Tags:       map[string]string{"service": "scim"},
 		},
 		Get: &plugin.GetConfig{
-			KeyColumns:        plugin.SingleColumn("id"),
-			ShouldIgnoreError: isNotFoundError([]string{"SCIM_404"}),
-			Hydrate:           getIAMAccountGroup,
+			KeyColumns: plugin.SingleColumn("id"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"SCIM_404"}),
+			},
+			Hydrate: getIAMAccountGroup,
+			Tags:    map[string]string{"service": "scim"},
 		},
 		Columns: databricksAccountColumns([]*plugin.Column{
 			{
@@ -106,10 +104,7 @@ func listIAMAccountGroups(ctx context.Context, d *plugin.QueryData, h *plugin.Hy
 		return nil, err
 	}
 
-	filterQuals := []filterQualMap{
-		{"display_name", "displayName", "string"},
-	}
-	filter := buildQueryFilterFromQuals(filterQuals, d.Quals)
+	filter := buildSCIMFilter(scimGroupFilterAttributes, d.Quals)
 
 	request := iam.ListAccountGroupsRequest{
 		Count:      int(maxLimit),
@@ -16,29 +16,17 @@ func tableDatabricksIAMAccountUser(_ context.Context) *plugin.Table {
 		Name:        "databricks_iam_account_user",
 		Description: "List details for all the users associated with a Databricks account.",
 		List: &plugin.ListConfig{
-			KeyColumns: []*plugin.KeyColumn{
-				{
-					Name:      "id",
-					Require:   plugin.Optional,
-					Operators: []string{"=", "<>"},
-				},
-				{
-					Name:      "user_name",
-					Require:   plugin.Optional,
-					Operators: []string{"=", "<>"},
-				},
-				{
-					Name:      "display_name",
-					Require:   plugin.Optional,
-					Operators: []string{"=", "<>"},
-				},
-			},
-			Hydrate: listIAMAccountUsers,
+			KeyColumns: scimKeyColumns(scimUserFilterAttributes),
+			Hydrate:    listIAMAccountUsers,
+			Tags:       map[string]string{"service": "scim"},
 		},
 		Get: &plugin.GetConfig{
-			KeyColumns:        plugin.SingleColumn("id"),
-			ShouldIgnoreError: isNotFoundError([]string{"SCIM_404"}),
-			Hydrate:           getIAMAccountUser,
+			KeyColumns: plugin.SingleColumn("id"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"SCIM_404"}),
+			},
+			Hydrate: getIAMAccountUser,
+			Tags:    map[string]string{"service": "scim"},
 		},
 		Columns: databricksAccountColumns([]*plugin.Column{
 			{
@@ -126,13 +114,7 @@ func listIAMAccountUsers(ctx context.Context, d *plugin.QueryData, h *plugin.Hyd
 		return nil, err
 	}
 
-	filterQuals := []filterQualMap{
-		{"id", "id", "string"},
-		{"user_name", "userName", "string"},
-		{"display_name", "displayName", "string"},
-	}
-
-	filter := buildQueryFilterFromQuals(filterQuals, d.Quals)
+	filter := buildSCIMFilter(scimUserFilterAttributes, d.Quals)
 
 	request := iam.ListAccountUsersRequest{
 		Count:      int(maxLimit),
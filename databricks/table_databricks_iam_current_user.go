@@ -16,8 +16,10 @@ func tableDatabricksIAMCurrentUser(_ context.Context) *plugin.Table {
 		Description: "Gets details for the current user of the workspace.",
 		List: &plugin.ListConfig{
 			Hydrate: getIAMCurrentUser,
+			Tags:    map[string]string{"service": "scim"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "id",
 				Description: "Databricks user ID.",
@@ -3,7 +3,6 @@ package databricks
 import (
 	"context"
 
-	"github.com/databricks/databricks-sdk-go/service/iam"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
@@ -16,21 +15,20 @@ func tableDatabricksIAMServicePrincipal(_ context.Context) *plugin.Table {
 		Name:        "databricks_iam_service_principal",
 		Description: "List the set of service principals associated with a Databricks workspace.",
 		List: &plugin.ListConfig{
-			KeyColumns: []*plugin.KeyColumn{
-				{
-					Name:      "display_name",
-					Require:   plugin.Optional,
-					Operators: []string{"=", "<>"},
-				},
-			},
-			Hydrate: listIAMServicePrincipals,
+			KeyColumns: scimKeyColumns(scimWorkspaceServicePrincipalFilterAttributes),
+			Hydrate:    listIAMServicePrincipals,
+			Tags:       map[string]string{"service": "scim"},
 		},
 		Get: &plugin.GetConfig{
-			KeyColumns:        plugin.SingleColumn("id"),
-			ShouldIgnoreError: isNotFoundError([]string{"SCIM_404"}),
-			Hydrate:           getIAMServicePrincipal,
+			KeyColumns: plugin.SingleColumn("id"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"SCIM_404"}),
+			},
+			Hydrate: getIAMServicePrincipal,
+			Tags:    map[string]string{"service": "scim"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "id",
 				Description: "Databricks service principal ID.",
@@ -56,6 +54,12 @@ func tableDatabricksIAMServicePrincipal(_ context.Context) *plugin.Table {
 				Description: "External id of the service principal.",
 				Type:        proto.ColumnType_STRING,
 			},
+			{
+				Name:        "created",
+				Description: "The time the service principal was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Meta.Created").Transform(transform.NullIfZeroValue),
+			},
 
 			// JSON fields
 			{
@@ -88,57 +92,12 @@ func tableDatabricksIAMServicePrincipal(_ context.Context) *plugin.Table {
 //// LIST FUNCTION
 
 func listIAMServicePrincipals(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	logger := plugin.Logger(ctx)
-
-	// Limiting the results
-	maxLimit := int32(10000)
-	if d.QueryContext.Limit != nil {
-		limit := int32(*d.QueryContext.Limit)
-		if limit < maxLimit {
-			maxLimit = limit
-		}
-	}
-
-	// Create client
-	client, err := getWorkspaceClient(ctx, d)
+	err := listSCIMResources[scimServicePrincipal](ctx, d, "/api/2.0/preview/scim/v2/ServicePrincipals", scimWorkspaceServicePrincipalFilterAttributes)
 	if err != nil {
-		logger.Error("databricks_iam_service_principal.listIAMServicePrincipals", "connection_error", err)
+		plugin.Logger(ctx).Error("databricks_iam_service_principal.listIAMServicePrincipals", "api_error", err)
 		return nil, err
 	}
-
-	filterQuals := []filterQualMap{
-		{"display_name", "displayName", "string"},
-	}
-	filter := buildQueryFilterFromQuals(filterQuals, d.Quals)
-
-	request := iam.ListServicePrincipalsRequest{
-		Count:      int(maxLimit),
-		StartIndex: 1,
-		Filter:     filter,
-	}
-
-	for {
-		principals, err := client.ServicePrincipals.ListAll(ctx, request)
-		if err != nil {
-			logger.Error("databricks_iam_service_principal.listIAMServicePrincipals", "api_error", err)
-			return nil, err
-		}
-
-		for _, item := range principals {
-			d.StreamListItem(ctx, item)
-
-			// Context can be cancelled due to manual cancellation or if the limit has been hit
-			if d.RowsRemaining(ctx) == 0 {
-				return nil, nil
-			}
-		}
-
-		if len(principals) < request.Count {
-			return nil, nil
-		} else {
-			request.StartIndex += request.Count
-		}
-	}
+	return nil, nil
 }
 
 //// HYDRATE FUNCTIONS
@@ -152,14 +111,7 @@ func getIAMServicePrincipal(ctx context.Context, d *plugin.QueryData, _ *plugin.
 		return nil, nil
 	}
 
-	// Create client
-	client, err := getWorkspaceClient(ctx, d)
-	if err != nil {
-		logger.Error("databricks_iam_service_principal.getIAMServicePrincipal", "connection_error", err)
-		return nil, err
-	}
-
-	principal, err := client.ServicePrincipals.GetById(ctx, id)
+	principal, err := getSCIMResource[scimServicePrincipal](ctx, d, "/api/2.0/preview/scim/v2/ServicePrincipals", id)
 	if err != nil {
 		logger.Error("databricks_iam_service_principal.getIAMServicePrincipal", "api_error", err)
 		return nil, err
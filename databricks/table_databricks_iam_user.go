@@ -3,7 +3,6 @@ package databricks
 import (
 	"context"
 
-	"github.com/databricks/databricks-sdk-go/service/iam"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
@@ -16,31 +15,20 @@ func tableDatabricksIAMUser(_ context.Context) *plugin.Table {
 		Name:        "databricks_iam_user",
 		Description: "List details for all the users associated with a Databricks workspace.",
 		List: &plugin.ListConfig{
-			KeyColumns: []*plugin.KeyColumn{
-				{
-					Name:      "id",
-					Require:   plugin.Optional,
-					Operators: []string{"=", "<>"},
-				},
-				{
-					Name:      "user_name",
-					Require:   plugin.Optional,
-					Operators: []string{"=", "<>"},
-				},
-				{
-					Name:      "display_name",
-					Require:   plugin.Optional,
-					Operators: []string{"=", "<>"},
-				},
-			},
-			Hydrate: listIAMUsers,
+			KeyColumns: scimKeyColumns(scimWorkspaceUserFilterAttributes),
+			Hydrate:    listIAMUsers,
+			Tags:       map[string]string{"service": "scim"},
 		},
 		Get: &plugin.GetConfig{
-			KeyColumns:        plugin.SingleColumn("id"),
-			ShouldIgnoreError: isNotFoundError([]string{"SCIM_404"}),
-			Hydrate:           getIAMUser,
+			KeyColumns: plugin.SingleColumn("id"),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"SCIM_404"}),
+			},
+			Hydrate: getIAMUser,
+			Tags:    map[string]string{"service": "scim"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "id",
 				Description: "Databricks user ID.",
@@ -66,6 +54,12 @@ func tableDatabricksIAMUser(_ context.Context) *plugin.Table {
 				Description: "External ID of the user.",
 				Type:        proto.ColumnType_STRING,
 			},
+			{
+				Name:        "created",
+				Description: "The time the user was created.",
+				Type:        proto.ColumnType_TIMESTAMP,
+				Transform:   transform.FromField("Meta.Created").Transform(transform.NullIfZeroValue),
+			},
 
 			// JSON fields
 			{
@@ -108,60 +102,12 @@ func tableDatabricksIAMUser(_ context.Context) *plugin.Table {
 //// LIST FUNCTION
 
 func listIAMUsers(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
-	logger := plugin.Logger(ctx)
-
-	// Limiting the results
-	maxLimit := int32(10000)
-	if d.QueryContext.Limit != nil {
-		limit := int32(*d.QueryContext.Limit)
-		if limit < maxLimit {
-			maxLimit = limit
-		}
-	}
-
-	// Create client
-	client, err := getWorkspaceClient(ctx, d)
+	err := listSCIMResources[scimUser](ctx, d, "/api/2.0/preview/scim/v2/Users", scimWorkspaceUserFilterAttributes)
 	if err != nil {
-		logger.Error("databricks_iam_user.listIAMUsers", "connection_error", err)
+		plugin.Logger(ctx).Error("databricks_iam_user.listIAMUsers", "api_error", err)
 		return nil, err
 	}
-
-	filterQuals := []filterQualMap{
-		{"id", "id", "string"},
-		{"user_name", "userName", "string"},
-		{"display_name", "displayName", "string"},
-	}
-
-	filter := buildQueryFilterFromQuals(filterQuals, d.Quals)
-
-	request := iam.ListUsersRequest{
-		Count:      int(maxLimit),
-		StartIndex: 1,
-		Filter:     filter,
-	}
-
-	for {
-		response, err := client.Users.Impl().List(ctx, request)
-		if err != nil {
-			logger.Error("databricks_iam_user.listIAMUsers", "api_error", err)
-			return nil, err
-		}
-
-		for _, item := range response.Resources {
-			d.StreamListItem(ctx, item)
-
-			// Context can be cancelled due to manual cancellation or the limit has been hit
-			if d.RowsRemaining(ctx) == 0 {
-				return nil, nil
-			}
-		}
-
-		if response.ItemsPerPage < int64(request.Count) {
-			return nil, nil
-		} else {
-			request.StartIndex = request.StartIndex + request.Count
-		}
-	}
+	return nil, nil
 }
 
 //// HYDRATE FUNCTIONS
@@ -175,18 +121,10 @@ func getIAMUser(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData)
 		return nil, nil
 	}
 
-	// Create client
-	client, err := getWorkspaceClient(ctx, d)
-	if err != nil {
-		logger.Error("databricks_iam_user.getIAMUser", "connection_error", err)
-		return nil, err
-	}
-
-	user, err := client.Users.GetById(ctx, id)
+	user, err := getSCIMResource[scimUser](ctx, d, "/api/2.0/preview/scim/v2/Users", id)
 	if err != nil {
 		logger.Error("databricks_iam_user.getIAMUser", "api_error", err)
 		return nil, err
 	}
-
 	return *user, nil
 }
@@ -0,0 +1,139 @@
+package databricks
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestListIAMUsers(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_iam_user", []string{"id", "user_name", "active"}, nil, 0)
+
+	want := []string{"alice@example.com", "bob@example.com", "carol@example.com", "dave@example.com", "erin@example.com"}
+	if got := columnStrings(rows, "user_name"); !reflect.DeepEqual(got, want) {
+		t.Errorf("user_name = %v, want %v", got, want)
+	}
+	if got := rowWith(t, rows, "user_name", "carol@example.com")["active"]; got != false {
+		t.Errorf("active = %v, want false", got)
+	}
+}
+
+func TestListIAMUsersLimit(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_iam_user", []string{"id"}, nil, 2)
+
+	if len(rows) != 2 {
+		t.Fatalf("got %d rows, want 2", len(rows))
+	}
+	// The limit is passed as the page size so that a single page is fetched
+	requests := fake.requestsTo("/api/2.0/preview/scim/v2/Users")
+	if len(requests) != 1 {
+		t.Fatalf("got %d list requests, want 1", len(requests))
+	}
+	if got := requests[0].Query.Get("count"); got != "2" {
+		t.Errorf("count = %q, want %q", got, "2")
+	}
+}
+
+func TestListIAMUsersFilterPushdown(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_iam_user", []string{"id", "user_name"}, []*proto.Qual{
+		qual("user_name", "=", "bob@example.com"),
+	}, 0)
+
+	if got := columnStrings(rows, "id"); !reflect.DeepEqual(got, []string{"1002"}) {
+		t.Errorf("id = %v, want [1002]", got)
+	}
+	requests := fake.requestsTo("/api/2.0/preview/scim/v2/Users")
+	if len(requests) != 1 {
+		t.Fatalf("got %d list requests, want 1", len(requests))
+	}
+	if got, want := requests[0].Query.Get("filter"), `userName eq "bob@example.com"`; got != want {
+		t.Errorf("filter = %q, want %q", got, want)
+	}
+}
+
+func TestGetIAMUser(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_iam_user", []string{"id", "display_name"}, []*proto.Qual{
+		qual("id", "=", "1003"),
+	}, 0)
+
+	if len(rows) != 1 || rows[0]["display_name"] != "Carol Analyst" {
+		t.Fatalf("got %v, want Carol Analyst", rows)
+	}
+	if len(fake.requestsTo("/api/2.0/preview/scim/v2/Users/1003")) != 1 {
+		t.Errorf("expected the user to be fetched by ID")
+	}
+}
+
+func TestGetIAMUserNotFound(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_iam_user", []string{"id"}, []*proto.Qual{
+		qual("id", "=", "9999"),
+	}, 0)
+
+	if len(rows) != 0 {
+		t.Errorf("got %d rows, want 0", len(rows))
+	}
+}
+
+func TestListIAMUsersLikeAndBooleanPushdown(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_iam_user", []string{"id", "user_name", "active"}, []*proto.Qual{
+		qual("user_name", "~~", "%example.com"),
+		qual("display_name", "~~", "C%"),
+		qual("active", "=", false),
+	}, 0)
+
+	if got := columnStrings(rows, "id"); !reflect.DeepEqual(got, []string{"1003"}) {
+		t.Errorf("id = %v, want [1003]", got)
+	}
+	requests := fake.requestsTo("/api/2.0/preview/scim/v2/Users")
+	if len(requests) != 1 {
+		t.Fatalf("got %d list requests, want 1", len(requests))
+	}
+	// Suffix matches cannot be expressed in SCIM and are left to Postgres
+	if got, want := requests[0].Query.Get("filter"), `displayName sw "C" and active eq false`; got != want {
+		t.Errorf("filter = %q, want %q", got, want)
+	}
+}
+
+func TestListIAMUsersCreatedPushdown(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_iam_user", []string{"id", "created"}, []*proto.Qual{
+		qual("created", ">=", time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)),
+		qual("created", "<", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)),
+	}, 0)
+
+	if got := columnStrings(rows, "id"); !reflect.DeepEqual(got, []string{"1003", "1004"}) {
+		t.Errorf("id = %v, want [1003 1004]", got)
+	}
+	if got, want := rowWith(t, rows, "id", "1003")["created"], time.Date(2023, 6, 20, 8, 0, 0, 0, time.UTC); got != want {
+		t.Errorf("created = %v, want %v", got, want)
+	}
+	requests := fake.requestsTo("/api/2.0/preview/scim/v2/Users")
+	if len(requests) != 1 {
+		t.Fatalf("got %d list requests, want 1", len(requests))
+	}
+	if got, want := requests[0].Query.Get("filter"), `meta.created ge "2023-06-01T00:00:00Z" and meta.created lt "2024-06-01T00:00:00Z"`; got != want {
+		t.Errorf("filter = %q, want %q", got, want)
+	}
+}
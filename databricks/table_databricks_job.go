@@ -20,12 +20,28 @@ func tableDatabricksJob(_ context.Context) *plugin.Table {
 		List: &plugin.ListConfig{
 			Hydrate:    listJobs,
 			KeyColumns: plugin.OptionalColumns([]string{"name"}),
+			Tags:       map[string]string{"service": "jobs"},
 		},
 		Get: &plugin.GetConfig{
 			KeyColumns: plugin.SingleColumn("job_id"),
 			Hydrate:    getJob,
+			Tags:       map[string]string{"service": "jobs"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getJobPermissions,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "jobs"},
+			},
+			{
+				Func: getJob,
+				Tags: map[string]string{"service": "jobs"},
+			},
+		},
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "job_id",
 				Description: "The canonical identifier for this job.",
@@ -0,0 +1,256 @@
+package databricks
+
+import (
+	"context"
+
+	"github.com/databricks/databricks-sdk-go/service/compute"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableDatabricksJobCluster(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "databricks_job_cluster",
+		Description: "List the clusters defined in each job, both shared job clusters and the new clusters of individual tasks.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listJobsForChild,
+			Hydrate:       listJobClusters,
+			KeyColumns:    plugin.OptionalColumns([]string{"job_id"}),
+			ParentTags:    map[string]string{"service": "jobs"},
+			Tags:          map[string]string{"service": "jobs"},
+		},
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
+			{
+				Name:        "job_id",
+				Description: "The canonical identifier of the job.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "job_name",
+				Description: "The name of the job.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "job_cluster_key",
+				Description: "The key of a job cluster shared by the tasks of the job. Null for the new cluster of a single task.",
+				Transform:   transform.FromField("JobClusterKey").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "task_key",
+				Description: "The key of the task for which the new cluster is created. Null for a shared job cluster.",
+				Transform:   transform.FromField("TaskKey").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "cluster_name",
+				Description: "The cluster name requested by the user.",
+				Transform:   transform.FromField("NewCluster.ClusterName").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "spark_version",
+				Description: "The Spark version of the cluster.",
+				Transform:   transform.FromField("NewCluster.SparkVersion").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "node_type_id",
+				Description: "The node type of the Spark workers.",
+				Transform:   transform.FromField("NewCluster.NodeTypeId").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "driver_node_type_id",
+				Description: "The node type of the Spark driver.",
+				Transform:   transform.FromField("NewCluster.DriverNodeTypeId").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "num_workers",
+				Description: "The number of worker nodes of the cluster, when it does not autoscale.",
+				Transform:   transform.FromField("NewCluster.NumWorkers"),
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "autoscale_min_workers",
+				Description: "The minimum number of workers the cluster can scale down to.",
+				Transform:   transform.FromField("NewCluster.Autoscale.MinWorkers"),
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "autoscale_max_workers",
+				Description: "The maximum number of workers the cluster can scale up to.",
+				Transform:   transform.FromField("NewCluster.Autoscale.MaxWorkers"),
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "policy_id",
+				Description: "The ID of the cluster policy used to create the cluster, if any.",
+				Transform:   transform.FromField("NewCluster.PolicyId").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "data_security_mode",
+				Description: "The data governance model of the cluster, e.g. SINGLE_USER or USER_ISOLATION.",
+				Transform:   transform.FromField("NewCluster.DataSecurityMode").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "single_user_name",
+				Description: "The user who can use the cluster in single user mode.",
+				Transform:   transform.FromField("NewCluster.SingleUserName").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "runtime_engine",
+				Description: "The runtime engine of the cluster, either STANDARD or PHOTON.",
+				Transform:   transform.FromField("NewCluster.RuntimeEngine").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "instance_pool_id",
+				Description: "The ID of the instance pool the cluster's workers belong to.",
+				Transform:   transform.FromField("NewCluster.InstancePoolId").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "driver_instance_pool_id",
+				Description: "The ID of the instance pool the cluster's driver belongs to.",
+				Transform:   transform.FromField("NewCluster.DriverInstancePoolId").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "autotermination_minutes",
+				Description: "The number of minutes of inactivity after which the cluster is terminated.",
+				Transform:   transform.FromField("NewCluster.AutoterminationMinutes"),
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "enable_local_disk_encryption",
+				Description: "Whether encryption of disks locally attached to the cluster is enabled.",
+				Transform:   transform.FromField("NewCluster.EnableLocalDiskEncryption"),
+				Type:        proto.ColumnType_BOOL,
+			},
+
+			// JSON fields
+			{
+				Name:        "aws_attributes",
+				Description: "Attributes related to clusters running on Amazon Web Services.",
+				Transform:   transform.FromField("NewCluster.AwsAttributes"),
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "azure_attributes",
+				Description: "Attributes related to clusters running on Microsoft Azure.",
+				Transform:   transform.FromField("NewCluster.AzureAttributes"),
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "gcp_attributes",
+				Description: "Attributes related to clusters running on Google Cloud Platform.",
+				Transform:   transform.FromField("NewCluster.GcpAttributes"),
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "custom_tags",
+				Description: "Additional tags for the cluster resources.",
+				Transform:   transform.FromField("NewCluster.CustomTags"),
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "init_scripts",
+				Description: "The init scripts run when the cluster starts.",
+				Transform:   transform.FromField("NewCluster.InitScripts"),
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "spark_conf",
+				Description: "The Spark configuration key-value pairs of the cluster.",
+				Transform:   transform.FromField("NewCluster.SparkConf"),
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "spark_env_vars",
+				Description: "The environment variables of the cluster.",
+				Transform:   transform.FromField("NewCluster.SparkEnvVars"),
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "new_cluster",
+				Description: "The full specification of the cluster.",
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Standard Steampipe columns
+			{
+				Name:        "title",
+				Description: "The title of the resource.",
+				Transform:   transform.From(jobClusterTitle),
+				Type:        proto.ColumnType_STRING,
+			},
+		}),
+	}
+}
+
+// jobClusterInfo is a cluster specification defined in a job, either as a
+// shared job cluster or as the new cluster of a task.
+type jobClusterInfo struct {
+	JobId         int64
+	JobName       string
+	JobClusterKey string
+	TaskKey       string
+	NewCluster    *compute.ClusterSpec
+}
+
+//// LIST FUNCTION
+
+func listJobClusters(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	job, err := getJobForChild(ctx, d, h)
+	if err != nil || job.Settings == nil {
+		return nil, err
+	}
+
+	var clusters []jobClusterInfo
+	for _, cluster := range job.Settings.JobClusters {
+		clusters = append(clusters, jobClusterInfo{
+			JobClusterKey: cluster.JobClusterKey,
+			NewCluster:    cluster.NewCluster,
+		})
+	}
+	for _, task := range job.Settings.Tasks {
+		if task.NewCluster != nil {
+			clusters = append(clusters, jobClusterInfo{
+				TaskKey:    task.TaskKey,
+				NewCluster: task.NewCluster,
+			})
+		}
+	}
+
+	for _, cluster := range clusters {
+		cluster.JobId = job.JobId
+		cluster.JobName = job.Settings.Name
+		d.StreamListItem(ctx, cluster)
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func jobClusterTitle(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	cluster := d.HydrateItem.(jobClusterInfo)
+	if cluster.JobClusterKey != "" {
+		return cluster.JobClusterKey, nil
+	}
+	return cluster.TaskKey, nil
+}
@@ -0,0 +1,47 @@
+package databricks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestListJobClusters(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_job_cluster", []string{"job_id", "job_cluster_key", "task_key", "title", "spark_version", "node_type_id", "policy_id", "data_security_mode", "num_workers", "autoscale_max_workers"}, nil, 0)
+
+	if got, want := columnStrings(rows, "title"), []string{"etl", "transform"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("title = %v, want %v", got, want)
+	}
+
+	shared := rowWith(t, rows, "title", "etl")
+	if shared["job_id"] != int64(11) || shared["job_cluster_key"] != "etl" || shared["task_key"] != nil ||
+		shared["policy_id"] != "E0631F5C0D000001" || shared["data_security_mode"] != "SINGLE_USER" || shared["num_workers"] != int64(2) {
+		t.Errorf("job cluster = %v", shared)
+	}
+
+	task := rowWith(t, rows, "title", "transform")
+	if task["job_cluster_key"] != nil || task["task_key"] != "transform" || task["policy_id"] != nil ||
+		task["node_type_id"] != "i3.2xlarge" || task["autoscale_max_workers"] != int64(8) {
+		t.Errorf("task cluster = %v", task)
+	}
+}
+
+func TestListJobClustersJobIdNotFound(t *testing.T) {
+	// A job ID of 0 is not looked up, an unknown one is not found
+	for _, jobId := range []int{0, 99} {
+		fake := newFakeDatabricks(t)
+		p := newTestPlugin(t, fake)
+
+		rows := p.query(t, "databricks_job_cluster", []string{"job_id", "job_cluster_key"}, []*proto.Qual{
+			qual("job_id", "=", jobId),
+		}, 0)
+
+		if len(rows) != 0 {
+			t.Errorf("job_id = %d: got %d rows, want 0", jobId, len(rows))
+		}
+	}
+}
@@ -2,8 +2,9 @@ package databricks
 
 import (
 	"context"
-        "fmt"
-        "strings"
+	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/databricks/databricks-sdk-go/service/jobs"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
@@ -18,14 +19,23 @@ func tableDatabricksJobRun(_ context.Context) *plugin.Table {
 		Name:        "databricks_job_run",
 		Description: "List details for all the job runs.",
 		List: &plugin.ListConfig{
-			KeyColumns: plugin.OptionalColumns([]string{"job_id", "run_type"}),
-			Hydrate:    listJobRuns,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "job_id", Require: plugin.Optional},
+				{Name: "run_type", Require: plugin.Optional},
+				{Name: "start_time", Require: plugin.Optional, Operators: []string{"=", ">", ">=", "<", "<="}},
+				{Name: "life_cycle_state", Require: plugin.Optional},
+				{Name: "result_state", Require: plugin.Optional},
+			},
+			Hydrate: listJobRuns,
+			Tags:    map[string]string{"service": "jobs"},
 		},
 		Get: &plugin.GetConfig{
 			KeyColumns: plugin.SingleColumn("run_id"),
 			Hydrate:    getJobRun,
+			Tags:       map[string]string{"service": "jobs"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "run_id",
 				Description: "The canonical identifier of the run.",
@@ -67,6 +77,12 @@ func tableDatabricksJobRun(_ context.Context) *plugin.Table {
 				Description: "The canonical identifier of the job that contains this run.",
 				Type:        proto.ColumnType_INT,
 			},
+			{
+				Name:        "life_cycle_state",
+				Description: "The current life cycle state of the run, e.g. PENDING, RUNNING or TERMINATED.",
+				Transform:   transform.FromField("State.LifeCycleState"),
+				Type:        proto.ColumnType_STRING,
+			},
 			{
 				Name:        "number_in_job",
 				Description: "A unique identifier for this job run. This is set to the same value as `run_id`.",
@@ -87,6 +103,12 @@ func tableDatabricksJobRun(_ context.Context) *plugin.Table {
 				Description: "The URL to the detail page of the run.",
 				Type:        proto.ColumnType_STRING,
 			},
+			{
+				Name:        "result_state",
+				Description: "The result of the run, e.g. SUCCESS or FAILED. Only available once the run has completed.",
+				Transform:   transform.FromField("State.ResultState"),
+				Type:        proto.ColumnType_STRING,
+			},
 			{
 				Name:        "run_type",
 				Description: "The type of run.",
@@ -201,6 +223,8 @@ func listJobRuns(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData
 	if d.EqualsQuals["run_type"] != nil {
 		request.RunType = jobs.ListRunsRunType(d.EqualsQualString("run_type"))
 	}
+	setListRunsTimeRange(&request, d.Quals["start_time"])
+	setListRunsStateFilter(&request, d.Quals)
 
 	// Create client
 	client, err := getWorkspaceClient(ctx, d)
@@ -212,10 +236,10 @@ func listJobRuns(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData
 	for {
 		response, err := client.Jobs.Impl().ListRuns(ctx, request)
 		if err != nil {
-                        if strings.Contains(err.Error(), fmt.Sprintf("Job %v does not exist", request.JobId)) {
-                                logger.Warn("databricks_job_run.listJobRuns", "api_error", err)
-                                return nil, nil
-                        }
+			if strings.Contains(err.Error(), fmt.Sprintf("Job %v does not exist", request.JobId)) {
+				logger.Warn("databricks_job_run.listJobRuns", "api_error", err)
+				return nil, nil
+			}
 			logger.Error("databricks_job_run.listJobRuns", "api_error", err)
 			return nil, err
 		}
@@ -239,6 +263,70 @@ func listJobRuns(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData
 
 //// HYDRATE FUNCTIONS
 
+// Life cycle states returned by the active_only and completed_only filters of
+// the list API
+var (
+	jobRunActiveStates    = []string{"PENDING", "RUNNING", "TERMINATING"}
+	jobRunCompletedStates = []string{"TERMINATED", "SKIPPED", "INTERNAL_ERROR"}
+)
+
+// setListRunsTimeRange narrows the list request to the start_time range of the
+// quals. The API bounds are inclusive milliseconds, so the range may include a
+// few extra runs at its edges which Postgres filters out.
+func setListRunsTimeRange(request *jobs.ListRunsRequest, startTimeQuals *plugin.KeyColumnQuals) {
+	if startTimeQuals == nil {
+		return
+	}
+	for _, q := range startTimeQuals.Quals {
+		timestamp := q.Value.GetTimestampValue()
+		if timestamp == nil {
+			continue
+		}
+		ms := int(timestamp.AsTime().UnixMilli())
+		switch q.Operator {
+		case "=":
+			request.StartTimeFrom = max(request.StartTimeFrom, ms)
+			request.StartTimeTo = minTime(request.StartTimeTo, ms)
+		case ">", ">=":
+			request.StartTimeFrom = max(request.StartTimeFrom, ms)
+		case "<", "<=":
+			request.StartTimeTo = minTime(request.StartTimeTo, ms)
+		}
+	}
+}
+
+// setListRunsStateFilter sets active_only or completed_only when the
+// life_cycle_state quals only match active or completed runs. A result state
+// is only set once a run has completed.
+func setListRunsStateFilter(request *jobs.ListRunsRequest, keyColumnQuals plugin.KeyColumnQualMap) {
+	activeOnly, completedOnly := false, keyColumnQuals["result_state"] != nil
+
+	var states []string
+	for _, value := range qualValues(keyColumnQuals["life_cycle_state"]) {
+		states = append(states, value.GetStringValue())
+	}
+	if len(states) > 0 {
+		activeOnly = allIn(states, jobRunActiveStates)
+		completedOnly = completedOnly || allIn(states, jobRunCompletedStates)
+	}
+
+	// The API rejects both filters at once, no run can match them anyway
+	if activeOnly && completedOnly {
+		return
+	}
+	request.ActiveOnly = activeOnly
+	request.CompletedOnly = completedOnly
+}
+
+func allIn(values, set []string) bool {
+	for _, value := range values {
+		if !slices.Contains(set, value) {
+			return false
+		}
+	}
+	return true
+}
+
 func getJobRun(ctx context.Context, d *plugin.QueryData, _ *plugin.HydrateData) (interface{}, error) {
 	logger := plugin.Logger(ctx)
 	id := d.EqualsQuals["run_id"].GetInt64Value()
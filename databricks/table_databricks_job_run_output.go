@@ -0,0 +1,359 @@
+package databricks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// Default maximum size in bytes of each output field, overridden by the
+// max_job_run_output_size connection argument
+const defaultMaxJobRunOutputSize = 65536
+
+// Error codes returned for a task run whose output is not available as the
+// task has not started yet
+var jobRunOutputUnavailableErrors = []string{"INVALID_STATE"}
+
+// The Jobs API reports an unknown or expired run as an invalid parameter, so
+// the message tells it apart from other invalid requests
+var jobRunNotFoundPattern = regexp.MustCompile(`^Run \d+ does not exist`)
+
+//// TABLE DEFINITION
+
+func tableDatabricksJobRunOutput(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "databricks_job_run_output",
+		Description: "Get the output and errors of a job run.",
+		List: &plugin.ListConfig{
+			KeyColumns: plugin.SingleColumn("run_id"),
+			Hydrate:    listJobRunOutputs,
+			Tags:       map[string]string{"service": "jobs"},
+		},
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
+			{
+				Name:        "run_id",
+				Description: "The canonical identifier of the job run or task run.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "task_run_id",
+				Description: "The canonical identifier of the task run the output belongs to. The same as run_id, unless run_id is a job run with multiple tasks.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "task_key",
+				Description: "The key of the task the output belongs to, when run_id is a job run with multiple tasks.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "job_id",
+				Description: "The canonical identifier of the job that contains the run.",
+				Transform:   transform.FromField("RunOutput.Metadata.JobId"),
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "life_cycle_state",
+				Description: "The life cycle state of the run, e.g. RUNNING or TERMINATED.",
+				Transform:   transform.FromField("RunOutput.Metadata.State.LifeCycleState").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "result_state",
+				Description: "The result of the run, e.g. SUCCESS or FAILED.",
+				Transform:   transform.FromField("RunOutput.Metadata.State.ResultState").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "error",
+				Description: "An error message indicating why the run failed or why output is not available.",
+				Transform:   transform.FromField("RunOutput.Error").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "error_trace",
+				Description: "The stack trace of the error, if any.",
+				Transform:   transform.FromField("RunOutput.ErrorTrace").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "output_error",
+				Description: "For a task of a job run with multiple tasks, the reason the output of the task could not be retrieved, e.g. as the task has not started yet or its output has expired.",
+				Transform:   transform.FromField("OutputError").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "notebook_result",
+				Description: "The value passed to dbutils.notebook.exit() by a notebook task.",
+				Transform:   transform.FromField("RunOutput.NotebookOutput.Result").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "notebook_result_truncated",
+				Description: "True if the notebook result was truncated, either by Databricks or by max_job_run_output_size.",
+				Transform:   transform.FromField("RunOutput.NotebookOutput.Truncated"),
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "logs",
+				Description: "The output of tasks that write to standard streams, e.g. spark_jar_task, spark_python_task or python_wheel_task.",
+				Transform:   transform.FromField("RunOutput.Logs").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "logs_truncated",
+				Description: "True if the logs were truncated, either by Databricks or by max_job_run_output_size.",
+				Transform:   transform.FromField("RunOutput.LogsTruncated"),
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "output_truncated",
+				Description: "True if any output field was truncated to max_job_run_output_size.",
+				Type:        proto.ColumnType_BOOL,
+			},
+
+			// JSON fields
+			{
+				Name:        "dbt_output",
+				Description: "The output of a dbt task, if available. Each of its values is truncated to max_job_run_output_size.",
+				Transform:   transform.FromField("RunOutput.DbtOutput"),
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "sql_output",
+				Description: "The output of a SQL task, if available. Query texts and error messages are truncated, and lists of statements and widgets are cut short, to max_job_run_output_size.",
+				Transform:   transform.FromField("RunOutput.SqlOutput"),
+				Type:        proto.ColumnType_JSON,
+			},
+		}),
+	}
+}
+
+// jobRunOutputInfo is the output of a task run, along with the run it was
+// requested for.
+type jobRunOutputInfo struct {
+	jobs.RunOutput
+	RunId           int64
+	TaskRunId       int64
+	TaskKey         string
+	OutputTruncated bool
+	OutputError     string
+}
+
+//// LIST FUNCTION
+
+func listJobRunOutputs(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+	runId := d.EqualsQuals["run_id"].GetInt64Value()
+
+	// Create client
+	client, err := getWorkspaceClient(ctx, d)
+	if err != nil {
+		logger.Error("databricks_job_run_output.listJobRunOutputs", "connection_error", err)
+		return nil, err
+	}
+
+	maxSize := defaultMaxJobRunOutputSize
+	if config := GetConfig(d.Connection); config.MaxJobRunOutputSize != nil && *config.MaxJobRunOutputSize > 0 {
+		maxSize = *config.MaxJobRunOutputSize
+	}
+
+	output, err := client.Jobs.GetRunOutput(ctx, jobs.GetRunOutputRequest{RunId: runId})
+	if err == nil {
+		d.StreamListItem(ctx, newJobRunOutputInfo(*output, runId, runId, "", maxSize))
+		return nil, nil
+	}
+	if isJobRunNotFound(err) {
+		logger.Warn("databricks_job_run_output.listJobRunOutputs", "api_error", err)
+		return nil, nil
+	}
+	if !strings.Contains(err.Error(), "multiple tasks") {
+		logger.Error("databricks_job_run_output.listJobRunOutputs", "api_error", err)
+		return nil, err
+	}
+
+	// The output of a job run with multiple tasks is only available per task
+	run, err := client.Jobs.GetRun(ctx, jobs.GetRunRequest{RunId: runId})
+	if err != nil {
+		logger.Error("databricks_job_run_output.listJobRunOutputs", "api_error", err)
+		return nil, err
+	}
+
+	for _, task := range run.Tasks {
+		output, err := client.Jobs.GetRunOutput(ctx, jobs.GetRunOutputRequest{RunId: task.RunId})
+		if err != nil {
+			if !isJobRunOutputUnavailable(err) {
+				logger.Error("databricks_job_run_output.listJobRunOutputs", "api_error", err, "task_run_id", task.RunId)
+				return nil, err
+			}
+			// A task without output must not hide the output of the other
+			// tasks, so it is returned with the reason in the output_error
+			// column, leaving the error column to the errors of the run itself
+			logger.Warn("databricks_job_run_output.listJobRunOutputs", "api_error", err, "task_run_id", task.RunId)
+			info := newJobRunOutputInfo(jobs.RunOutput{}, runId, task.RunId, task.TaskKey, maxSize)
+			info.OutputError = err.Error()
+			d.StreamListItem(ctx, info)
+		} else {
+			d.StreamListItem(ctx, newJobRunOutputInfo(*output, runId, task.RunId, task.TaskKey, maxSize))
+		}
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// newJobRunOutputInfo truncates each output field to maxSize bytes. Notebook
+// results and logs can be several megabytes.
+func newJobRunOutputInfo(output jobs.RunOutput, runId, taskRunId int64, taskKey string, maxSize int) jobRunOutputInfo {
+	info := jobRunOutputInfo{
+		RunId:     runId,
+		TaskRunId: taskRunId,
+		TaskKey:   taskKey,
+	}
+
+	var truncated bool
+	output.Error, truncated = truncateOutput(output.Error, maxSize)
+	info.OutputTruncated = truncated
+	output.ErrorTrace, truncated = truncateOutput(output.ErrorTrace, maxSize)
+	info.OutputTruncated = info.OutputTruncated || truncated
+	output.Logs, truncated = truncateOutput(output.Logs, maxSize)
+	info.OutputTruncated = info.OutputTruncated || truncated
+	output.LogsTruncated = output.LogsTruncated || truncated
+	if output.NotebookOutput != nil {
+		notebookOutput := *output.NotebookOutput
+		notebookOutput.Result, truncated = truncateOutput(notebookOutput.Result, maxSize)
+		info.OutputTruncated = info.OutputTruncated || truncated
+		notebookOutput.Truncated = notebookOutput.Truncated || truncated
+		output.NotebookOutput = &notebookOutput
+	}
+	if output.SqlOutput != nil {
+		var sqlOutput jobs.SqlOutput
+		sqlOutput, truncated = truncateSqlOutput(*output.SqlOutput, maxSize)
+		info.OutputTruncated = info.OutputTruncated || truncated
+		output.SqlOutput = &sqlOutput
+	}
+	if output.DbtOutput != nil {
+		var dbtOutput jobs.DbtOutput
+		dbtOutput, truncated = truncateDbtOutput(*output.DbtOutput, maxSize)
+		info.OutputTruncated = info.OutputTruncated || truncated
+		output.DbtOutput = &dbtOutput
+	}
+
+	info.RunOutput = output
+	return info
+}
+
+// truncateSqlOutput truncates the query texts and error messages of a SQL
+// task output, and cuts its lists of statements and widgets short.
+func truncateSqlOutput(output jobs.SqlOutput, maxSize int) (jobs.SqlOutput, bool) {
+	var truncated, anyTruncated bool
+	if output.QueryOutput != nil {
+		queryOutput := *output.QueryOutput
+		queryOutput.QueryText, truncated = truncateOutput(queryOutput.QueryText, maxSize)
+		anyTruncated = anyTruncated || truncated
+		queryOutput.SqlStatements, truncated = truncateOutputList(queryOutput.SqlStatements, maxSize)
+		anyTruncated = anyTruncated || truncated
+		output.QueryOutput = &queryOutput
+	}
+	if output.AlertOutput != nil {
+		alertOutput := *output.AlertOutput
+		alertOutput.QueryText, truncated = truncateOutput(alertOutput.QueryText, maxSize)
+		anyTruncated = anyTruncated || truncated
+		alertOutput.SqlStatements, truncated = truncateOutputList(alertOutput.SqlStatements, maxSize)
+		anyTruncated = anyTruncated || truncated
+		output.AlertOutput = &alertOutput
+	}
+	if output.DashboardOutput != nil {
+		dashboardOutput := *output.DashboardOutput
+		widgets := make([]jobs.SqlDashboardWidgetOutput, len(dashboardOutput.Widgets))
+		for i, widget := range dashboardOutput.Widgets {
+			if widget.Error != nil {
+				widgetError := *widget.Error
+				widgetError.Message, truncated = truncateOutput(widgetError.Message, maxSize)
+				anyTruncated = anyTruncated || truncated
+				widget.Error = &widgetError
+			}
+			widgets[i] = widget
+		}
+		dashboardOutput.Widgets, truncated = truncateOutputList(widgets, maxSize)
+		anyTruncated = anyTruncated || truncated
+		output.DashboardOutput = &dashboardOutput
+	}
+	return output, anyTruncated
+}
+
+// truncateDbtOutput truncates the artifacts link and headers of a dbt task
+// output.
+func truncateDbtOutput(output jobs.DbtOutput, maxSize int) (jobs.DbtOutput, bool) {
+	var truncated, anyTruncated bool
+	output.ArtifactsLink, anyTruncated = truncateOutput(output.ArtifactsLink, maxSize)
+	if output.ArtifactsHeaders != nil {
+		headers := make(map[string]string, len(output.ArtifactsHeaders))
+		for name, value := range output.ArtifactsHeaders {
+			headers[name], truncated = truncateOutput(value, maxSize)
+			anyTruncated = anyTruncated || truncated
+		}
+		output.ArtifactsHeaders = headers
+	}
+	return output, anyTruncated
+}
+
+// truncateOutputList keeps the leading items of a list whose JSON encoding
+// fits in maxSize bytes.
+func truncateOutputList[T any](items []T, maxSize int) ([]T, bool) {
+	size := 0
+	for i, item := range items {
+		data, _ := json.Marshal(item)
+		size += len(data)
+		if size > maxSize {
+			return items[:i], true
+		}
+	}
+	return items, false
+}
+
+// isJobRunOutputUnavailable returns true if the error means that the output
+// of a task run is not available, rather than that it could not be read.
+func isJobRunOutputUnavailable(err error) bool {
+	return isNotFoundError(jobRunOutputUnavailableErrors)(err) || isJobRunNotFound(err)
+}
+
+// isJobRunNotFound returns true if the error means that the run does not
+// exist, or no longer does.
+func isJobRunNotFound(err error) bool {
+	var apiErr *apierr.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	if apiErr.StatusCode == http.StatusNotFound || apiErr.ErrorCode == "RESOURCE_DOES_NOT_EXIST" {
+		return true
+	}
+	return apiErr.ErrorCode == "INVALID_PARAMETER_VALUE" && jobRunNotFoundPattern.MatchString(apiErr.Message)
+}
+
+// truncateOutput cuts a string to at most maxSize bytes without splitting a
+// multi-byte character.
+func truncateOutput(s string, maxSize int) (string, bool) {
+	if len(s) <= maxSize {
+		return s, false
+	}
+	end := maxSize
+	for end > 0 && !utf8.RuneStart(s[end]) {
+		end--
+	}
+	return s[:end], true
+}
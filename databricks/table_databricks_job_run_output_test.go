@@ -0,0 +1,172 @@
+package databricks
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestListJobRunOutputsTaskRun(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_job_run_output", []string{"run_id", "task_run_id", "error", "error_trace", "result_state", "output_truncated"}, []*proto.Qual{
+		qual("run_id", "=", 604),
+	}, 0)
+
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(rows))
+	}
+	row := rows[0]
+	if row["task_run_id"] != int64(604) || row["error"] != "ZeroDivisionError: division by zero" || row["result_state"] != "FAILED" {
+		t.Errorf("row = %v", row)
+	}
+	if row["output_truncated"] != false {
+		t.Errorf("output_truncated = %v, want false", row["output_truncated"])
+	}
+}
+
+func TestListJobRunOutputsMultiTaskRun(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_job_run_output", []string{"run_id", "task_run_id", "task_key", "notebook_result", "sql_output"}, []*proto.Qual{
+		qual("run_id", "=", 501),
+	}, 0)
+
+	if got, want := columnStrings(rows, "task_run_id"), []string{"601", "602"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("task_run_id = %v, want %v", got, want)
+	}
+	ingest := rowWith(t, rows, "task_key", "ingest")
+	if ingest["run_id"] != int64(501) || ingest["notebook_result"] != "ingested 42 rows" {
+		t.Errorf("ingest output = %v", ingest)
+	}
+	transform := rowWith(t, rows, "task_key", "transform")
+	if transform["sql_output"] == nil {
+		t.Errorf("sql_output = nil, want the query output")
+	}
+}
+
+func TestListJobRunOutputsMultiTaskRunUnavailableTask(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		setup     func(fake *fakeDatabricks)
+		wantError string
+	}{
+		{
+			name:      "pending task",
+			setup:     func(fake *fakeDatabricks) { fake.pendingOutputs["602"] = true },
+			wantError: "Run 602 has not started yet.",
+		},
+		{
+			name: "missing task",
+			setup: func(fake *fakeDatabricks) {
+				fake.outputs = slices.DeleteFunc(fake.outputs, func(output map[string]interface{}) bool {
+					return lookup(output, "run_id") == "602"
+				})
+			},
+			wantError: "Run 602 does not exist.",
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			fake := newFakeDatabricks(t)
+			test.setup(fake)
+			p := newTestPlugin(t, fake)
+
+			rows := p.query(t, "databricks_job_run_output", []string{"task_run_id", "task_key", "notebook_result", "error", "output_error"}, []*proto.Qual{
+				qual("run_id", "=", 501),
+			}, 0)
+
+			if got, want := columnStrings(rows, "task_run_id"), []string{"601", "602"}; !reflect.DeepEqual(got, want) {
+				t.Errorf("task_run_id = %v, want %v", got, want)
+			}
+			if ingest := rowWith(t, rows, "task_key", "ingest"); ingest["notebook_result"] != "ingested 42 rows" {
+				t.Errorf("ingest output = %v", ingest)
+			}
+			transform := rowWith(t, rows, "task_key", "transform")
+			if transform["output_error"] != test.wantError {
+				t.Errorf("transform output_error = %v, want %q", transform["output_error"], test.wantError)
+			}
+			// The error column is left to the errors of the run itself
+			if transform["error"] != nil {
+				t.Errorf("transform error = %v, want null", transform["error"])
+			}
+		})
+	}
+}
+
+func TestListJobRunOutputsUnknownRun(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_job_run_output", []string{"run_id"}, []*proto.Qual{
+		qual("run_id", "=", 999),
+	}, 0)
+
+	if len(rows) != 0 {
+		t.Errorf("got %d rows, want 0", len(rows))
+	}
+}
+
+func TestListJobRunOutputsMaxSize(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake, "max_job_run_output_size = 18")
+
+	rows := p.query(t, "databricks_job_run_output", []string{"logs", "logs_truncated", "output_truncated"}, []*proto.Qual{
+		qual("run_id", "=", 605),
+	}, 0)
+
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(rows))
+	}
+	// The ellipsis is a multi-byte character which does not fit in full
+	if got, want := rows[0]["logs"], "generating report"; got != want {
+		t.Errorf("logs = %q, want %q", got, want)
+	}
+	if rows[0]["logs_truncated"] != true || rows[0]["output_truncated"] != true {
+		t.Errorf("logs_truncated = %v, output_truncated = %v, want true", rows[0]["logs_truncated"], rows[0]["output_truncated"])
+	}
+}
+
+func TestListJobRunOutputsMaxSizeSqlOutput(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake, "max_job_run_output_size = 4")
+
+	rows := p.query(t, "databricks_job_run_output", []string{"task_key", "sql_output", "output_truncated"}, []*proto.Qual{
+		qual("run_id", "=", 602),
+	}, 0)
+
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(rows))
+	}
+	sqlOutput, _ := rows[0]["sql_output"].(map[string]interface{})
+	if got, want := lookup(sqlOutput, "query_output", "query_text"), "sele"; got != want {
+		t.Errorf("query_text = %q, want %q", got, want)
+	}
+	if rows[0]["output_truncated"] != true {
+		t.Errorf("output_truncated = %v, want true", rows[0]["output_truncated"])
+	}
+}
+
+func TestIsJobRunOutputUnavailable(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"pending", &apierr.APIError{ErrorCode: "INVALID_STATE", StatusCode: 400, Message: "Run 602 has not started yet."}, true},
+		{"unknown run", &apierr.APIError{ErrorCode: "INVALID_PARAMETER_VALUE", StatusCode: 400, Message: "Run 602 does not exist."}, true},
+		{"not found", &apierr.APIError{ErrorCode: "RESOURCE_DOES_NOT_EXIST", StatusCode: 404}, true},
+		{"other invalid parameter", &apierr.APIError{ErrorCode: "INVALID_PARAMETER_VALUE", StatusCode: 400, Message: "Cluster 0123 does not exist"}, false},
+		{"permission denied", &apierr.APIError{ErrorCode: "PERMISSION_DENIED", StatusCode: 403, Message: "Notebook /Users/bob does not exist or you lack access"}, false},
+		{"not an api error", errors.New("Run 602 does not exist."), false},
+	} {
+		if got := isJobRunOutputUnavailable(test.err); got != test.want {
+			t.Errorf("%s: isJobRunOutputUnavailable = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,353 @@
+package databricks
+
+import (
+	"context"
+
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+//// TABLE DEFINITION
+
+func tableDatabricksJobRunTask(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "databricks_job_run_task",
+		Description: "List the task attempts of each job run.",
+		List: &plugin.ListConfig{
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "run_id", Require: plugin.Optional},
+				{Name: "job_id", Require: plugin.Optional},
+				{Name: "run_type", Require: plugin.Optional},
+				{Name: "run_start_time", Require: plugin.Optional, Operators: []string{"=", ">", ">=", "<", "<="}},
+				{Name: "start_time", Require: plugin.Optional, Operators: []string{"<", "<="}},
+			},
+			Hydrate: listJobRunTasks,
+			// A run_id or job_id which does not exist is an invalid parameter
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"INVALID_PARAMETER_VALUE"}),
+			},
+			Tags: map[string]string{"service": "jobs"},
+		},
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
+			{
+				Name:        "run_id",
+				Description: "The canonical identifier of the job run the task belongs to.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "task_key",
+				Description: "A unique name for the task within its job.",
+				Transform:   transform.FromField("RunTask.TaskKey").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "task_run_id",
+				Description: "The canonical identifier of the task run.",
+				Transform:   transform.FromField("RunTask.RunId"),
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "attempt_number",
+				Description: "The sequence number of this task attempt. The first attempt has an attempt_number of 0, and each retry increments it.",
+				Transform:   transform.FromField("RunTask.AttemptNumber"),
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "job_id",
+				Description: "The canonical identifier of the job that contains the run.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "run_name",
+				Description: "The name of the job run the task belongs to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "run_type",
+				Description: "The type of the job run the task belongs to.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "run_start_time",
+				Description: "The time at which the job run started.",
+				Transform:   transform.FromField("RunStartTime").Transform(transform.UnixMsToTimestamp),
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "description",
+				Description: "An optional description for the task.",
+				Transform:   transform.FromField("RunTask.Description").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "task_type",
+				Description: "The type of the task, e.g. notebook, spark_python, python_wheel, sql or dbt.",
+				Transform:   transform.From(jobRunTaskType),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "life_cycle_state",
+				Description: "The current life cycle state of the task, e.g. PENDING, RUNNING or TERMINATED.",
+				Transform:   transform.FromField("RunTask.State.LifeCycleState").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "result_state",
+				Description: "The result of the task, e.g. SUCCESS or FAILED. Only available once the task has completed.",
+				Transform:   transform.FromField("RunTask.State.ResultState").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "state_message",
+				Description: "A descriptive message for the current state of the task.",
+				Transform:   transform.FromField("RunTask.State.StateMessage").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "start_time",
+				Description: "The time at which the task started.",
+				Transform:   transform.FromField("RunTask.StartTime").Transform(transform.UnixMsToTimestamp),
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "end_time",
+				Description: "The time at which the task ended.",
+				Transform:   transform.FromField("RunTask.EndTime").Transform(transform.UnixMsToTimestamp),
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "setup_duration",
+				Description: "The time in milliseconds it took to set up the cluster.",
+				Transform:   transform.FromField("RunTask.SetupDuration"),
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "execution_duration",
+				Description: "The time in milliseconds it took to execute the commands of the task.",
+				Transform:   transform.FromField("RunTask.ExecutionDuration"),
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "cleanup_duration",
+				Description: "The time in milliseconds it took to terminate the cluster and clean up any associated artifacts.",
+				Transform:   transform.FromField("RunTask.CleanupDuration"),
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "existing_cluster_id",
+				Description: "The ID of an existing cluster the task ran on.",
+				Transform:   transform.FromField("RunTask.ExistingClusterId").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "run_if",
+				Description: "The condition on its dependencies under which the task runs.",
+				Transform:   transform.FromField("RunTask.RunIf").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// JSON fields
+			{
+				Name:        "cluster_instance",
+				Description: "The cluster instance that was used to run the task.",
+				Transform:   transform.FromField("RunTask.ClusterInstance"),
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "depends_on",
+				Description: "The task keys of the tasks this task depends on.",
+				Transform:   transform.From(jobRunTaskDependsOn),
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "libraries",
+				Description: "The libraries installed on the cluster that ran the task.",
+				Transform:   transform.FromField("RunTask.Libraries"),
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "new_cluster",
+				Description: "The specification of the new cluster created for the task.",
+				Transform:   transform.FromField("RunTask.NewCluster"),
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "state",
+				Description: "The current state of the task.",
+				Transform:   transform.FromField("RunTask.State"),
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "task",
+				Description: "The settings of the task for its task type, e.g. the notebook path and parameters of a notebook task.",
+				Transform:   transform.From(jobRunTaskSettings),
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Standard Steampipe columns
+			{
+				Name:        "title",
+				Description: "The title of the resource.",
+				Transform:   transform.FromField("RunTask.TaskKey").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+		}),
+	}
+}
+
+// jobRunTaskInfo is a single task attempt along with the job run it belongs to.
+type jobRunTaskInfo struct {
+	jobs.RunTask
+	RunId        int64
+	JobId        int64
+	RunName      string
+	RunType      jobs.RunType
+	RunStartTime int64
+}
+
+//// LIST FUNCTION
+
+func listJobRunTasks(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	logger := plugin.Logger(ctx)
+
+	// Create client
+	client, err := getWorkspaceClient(ctx, d)
+	if err != nil {
+		logger.Error("databricks_job_run_task.listJobRunTasks", "connection_error", err)
+		return nil, err
+	}
+
+	// A single run can be fetched directly
+	if d.EqualsQuals["run_id"] != nil {
+		run, err := client.Jobs.GetRun(ctx, jobs.GetRunRequest{RunId: d.EqualsQuals["run_id"].GetInt64Value()})
+		if err != nil {
+			logger.Error("databricks_job_run_task.listJobRunTasks", "api_error", err)
+			return nil, err
+		}
+		streamJobRunTasks(ctx, d, run.RunId, run.JobId, run.RunName, run.RunType, run.StartTime, run.Tasks)
+		return nil, nil
+	}
+
+	// Every run has at least one task, so a page never needs more runs than
+	// the remaining rows
+	maxLimit := int32(25)
+	if d.QueryContext.Limit != nil {
+		limit := int32(*d.QueryContext.Limit)
+		if limit < maxLimit {
+			maxLimit = limit
+		}
+	}
+
+	request := jobs.ListRunsRequest{
+		Limit:       int(maxLimit),
+		ExpandTasks: true,
+	}
+	if d.EqualsQuals["job_id"] != nil {
+		request.JobId = d.EqualsQuals["job_id"].GetInt64Value()
+	}
+	if d.EqualsQuals["run_type"] != nil {
+		request.RunType = jobs.ListRunsRunType(d.EqualsQualString("run_type"))
+	}
+	setListRunsTimeRange(&request, d.Quals["run_start_time"])
+
+	// A task never starts before its run, so a task started before a time
+	// belongs to a run started before it too
+	setListRunsTimeRange(&request, d.Quals["start_time"])
+
+	for {
+		response, err := client.Jobs.Impl().ListRuns(ctx, request)
+		if err != nil {
+			logger.Error("databricks_job_run_task.listJobRunTasks", "api_error", err)
+			return nil, err
+		}
+
+		for _, run := range response.Runs {
+			if !streamJobRunTasks(ctx, d, run.RunId, run.JobId, run.RunName, run.RunType, run.StartTime, run.Tasks) {
+				return nil, nil
+			}
+		}
+
+		if response.HasMore {
+			request.PageToken = response.NextPageToken
+		} else {
+			return nil, nil
+		}
+	}
+}
+
+// streamJobRunTasks streams a row for each task attempt of a run, and returns
+// false once no more rows are needed.
+func streamJobRunTasks(ctx context.Context, d *plugin.QueryData, runId, jobId int64, runName string, runType jobs.RunType, runStartTime int64, tasks []jobs.RunTask) bool {
+	for _, task := range tasks {
+		d.StreamListItem(ctx, jobRunTaskInfo{
+			RunTask:      task,
+			RunId:        runId,
+			JobId:        jobId,
+			RunName:      runName,
+			RunType:      runType,
+			RunStartTime: runStartTime,
+		})
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+//// TRANSFORM FUNCTIONS
+
+func jobRunTaskDependsOn(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	task := d.HydrateItem.(jobRunTaskInfo)
+	if len(task.DependsOn) == 0 {
+		return nil, nil
+	}
+	var taskKeys []string
+	for _, dependency := range task.DependsOn {
+		taskKeys = append(taskKeys, dependency.TaskKey)
+	}
+	return taskKeys, nil
+}
+
+func jobRunTaskType(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	taskType, _ := jobRunTaskTypeAndSettings(d.HydrateItem.(jobRunTaskInfo).RunTask)
+	if taskType == "" {
+		return nil, nil
+	}
+	return taskType, nil
+}
+
+func jobRunTaskSettings(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	_, settings := jobRunTaskTypeAndSettings(d.HydrateItem.(jobRunTaskInfo).RunTask)
+	return settings, nil
+}
+
+// jobRunTaskTypeAndSettings returns the type of a task, named after its
+// settings field without the _task suffix, along with those settings.
+func jobRunTaskTypeAndSettings(task jobs.RunTask) (string, interface{}) {
+	switch {
+	case task.NotebookTask != nil:
+		return "notebook", task.NotebookTask
+	case task.SparkPythonTask != nil:
+		return "spark_python", task.SparkPythonTask
+	case task.PythonWheelTask != nil:
+		return "python_wheel", task.PythonWheelTask
+	case task.SparkJarTask != nil:
+		return "spark_jar", task.SparkJarTask
+	case task.SparkSubmitTask != nil:
+		return "spark_submit", task.SparkSubmitTask
+	case task.PipelineTask != nil:
+		return "pipeline", task.PipelineTask
+	case task.SqlTask != nil:
+		return "sql", task.SqlTask
+	case task.DbtTask != nil:
+		return "dbt", task.DbtTask
+	case task.ConditionTask != nil:
+		return "condition", task.ConditionTask
+	}
+	return "", nil
+}
@@ -0,0 +1,109 @@
+package databricks
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestListJobRunTasks(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_job_run_task", []string{"run_id", "task_run_id", "task_key", "attempt_number", "task_type", "result_state", "depends_on", "cluster_instance", "execution_duration"}, nil, 0)
+
+	if got, want := columnStrings(rows, "task_run_id"), []string{"601", "602", "603", "604", "605", "606"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("task_run_id = %v, want %v", got, want)
+	}
+
+	ingest := rowWith(t, rows, "task_run_id", "601")
+	if ingest["run_id"] != int64(501) || ingest["task_type"] != "notebook" || ingest["execution_duration"] != int64(290000) {
+		t.Errorf("ingest task = %v", ingest)
+	}
+	if got := ingest["cluster_instance"].(map[string]interface{})["cluster_id"]; got != "0801-101010-abcd1234" {
+		t.Errorf("cluster_instance.cluster_id = %v, want %v", got, "0801-101010-abcd1234")
+	}
+
+	transform := rowWith(t, rows, "task_run_id", "602")
+	if got, want := transform["depends_on"], []interface{}{"ingest"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("depends_on = %v, want %v", got, want)
+	}
+	if transform["task_type"] != "sql" {
+		t.Errorf("task_type = %v, want sql", transform["task_type"])
+	}
+
+	retry := rowWith(t, rows, "task_run_id", "604")
+	if retry["attempt_number"] != int64(1) || retry["result_state"] != "FAILED" {
+		t.Errorf("retried task = %v", retry)
+	}
+}
+
+func TestListJobRunTasksRunId(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_job_run_task", []string{"run_id", "task_run_id"}, []*proto.Qual{
+		qual("run_id", "=", 502),
+	}, 0)
+
+	if got, want := columnStrings(rows, "task_run_id"), []string{"603", "604"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("task_run_id = %v, want %v", got, want)
+	}
+	if requests := fake.requestsTo("/api/2.1/jobs/runs/list"); len(requests) != 0 {
+		t.Errorf("got %d list requests, want 0", len(requests))
+	}
+}
+
+func TestListJobRunTasksUnknownRunId(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_job_run_task", []string{"task_run_id"}, []*proto.Qual{
+		qual("run_id", "=", 999),
+	}, 0)
+
+	if len(rows) != 0 {
+		t.Errorf("got %d rows, want 0", len(rows))
+	}
+}
+
+func TestListJobRunTasksUnknownJobId(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_job_run_task", []string{"task_run_id"}, []*proto.Qual{
+		qual("job_id", "=", 999),
+	}, 0)
+
+	if len(rows) != 0 {
+		t.Errorf("got %d rows, want 0", len(rows))
+	}
+}
+
+func TestListJobRunTasksTimePushdown(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	p.query(t, "databricks_job_run_task", []string{"task_run_id"}, []*proto.Qual{
+		qual("job_id", "=", 11),
+		qual("run_start_time", ">", time.UnixMilli(1700000000000)),
+		qual("start_time", "<", time.UnixMilli(1700100000000)),
+	}, 0)
+
+	for _, request := range fake.requestsTo("/api/2.1/jobs/runs/list") {
+		if got := request.Query.Get("job_id"); got != "11" {
+			t.Errorf("job_id = %q, want %q", got, "11")
+		}
+		if got := request.Query.Get("start_time_from"); got != "1700000000000" {
+			t.Errorf("start_time_from = %q, want %q", got, "1700000000000")
+		}
+		if got := request.Query.Get("start_time_to"); got != "1700100000000" {
+			t.Errorf("start_time_to = %q, want %q", got, "1700100000000")
+		}
+		if got := request.Query.Get("expand_tasks"); got != "true" {
+			t.Errorf("expand_tasks = %q, want %q", got, "true")
+		}
+	}
+}
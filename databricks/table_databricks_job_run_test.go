@@ -0,0 +1,105 @@
+package databricks
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestListJobRunsStartTimePushdown(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	from := time.UnixMilli(1700086400000)
+	to := time.UnixMilli(1700100000000)
+	rows := p.query(t, "databricks_job_run", []string{"run_id", "start_time"}, []*proto.Qual{
+		qual("start_time", ">=", from),
+		qual("start_time", "<", to),
+	}, 0)
+
+	if got, want := columnStrings(rows, "run_id"), []string{"502", "503"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("run_id = %v, want %v", got, want)
+	}
+	for _, request := range fake.requestsTo("/api/2.1/jobs/runs/list") {
+		if got := request.Query.Get("start_time_from"); got != "1700086400000" {
+			t.Errorf("start_time_from = %q, want %q", got, "1700086400000")
+		}
+		if got := request.Query.Get("start_time_to"); got != "1700100000000" {
+			t.Errorf("start_time_to = %q, want %q", got, "1700100000000")
+		}
+	}
+}
+
+// The runs returned by the fake API are not filtered any further, in a real
+// query Postgres removes those which do not match the quals.
+func TestListJobRunsStatePushdown(t *testing.T) {
+	tests := []struct {
+		name      string
+		quals     []*proto.Qual
+		wantRuns  []string
+		wantQuery map[string]string
+	}{
+		{
+			name:      "active life cycle state",
+			quals:     []*proto.Qual{qual("life_cycle_state", "=", "RUNNING")},
+			wantRuns:  []string{"503", "504"},
+			wantQuery: map[string]string{"active_only": "true", "completed_only": ""},
+		},
+		{
+			name:      "completed life cycle state",
+			quals:     []*proto.Qual{qual("life_cycle_state", "=", "TERMINATED")},
+			wantRuns:  []string{"501", "502"},
+			wantQuery: map[string]string{"active_only": "", "completed_only": "true"},
+		},
+		{
+			name:      "result state",
+			quals:     []*proto.Qual{qual("result_state", "=", "FAILED")},
+			wantRuns:  []string{"501", "502"},
+			wantQuery: map[string]string{"active_only": "", "completed_only": "true"},
+		},
+		{
+			name:      "blocked life cycle state",
+			quals:     []*proto.Qual{qual("life_cycle_state", "=", "BLOCKED")},
+			wantRuns:  []string{"501", "502", "503", "504"},
+			wantQuery: map[string]string{"active_only": "", "completed_only": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeDatabricks(t)
+			p := newTestPlugin(t, fake)
+
+			rows := p.query(t, "databricks_job_run", []string{"run_id", "life_cycle_state", "result_state"}, tt.quals, 0)
+
+			if got := columnStrings(rows, "run_id"); !reflect.DeepEqual(got, tt.wantRuns) {
+				t.Errorf("run_id = %v, want %v", got, tt.wantRuns)
+			}
+			for _, request := range fake.requestsTo("/api/2.1/jobs/runs/list") {
+				for param, want := range tt.wantQuery {
+					if got := request.Query.Get(param); got != want {
+						t.Errorf("%s = %q, want %q", param, got, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestListJobRunsPageSize(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_job_run", []string{"run_id"}, nil, 0)
+
+	if len(rows) != 4 {
+		t.Fatalf("got %d rows, want 4", len(rows))
+	}
+	for _, request := range fake.requestsTo("/api/2.1/jobs/runs/list") {
+		if got := request.Query.Get("limit"); got != "25" {
+			t.Errorf("limit = %q, want %q", got, "25")
+		}
+	}
+}
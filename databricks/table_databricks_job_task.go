@@ -0,0 +1,300 @@
+package databricks
+
+import (
+	"context"
+
+	"github.com/databricks/databricks-sdk-go/service/jobs"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
+)
+
+// A job_id of a job which does not exist is an invalid parameter
+var jobNotFoundErrors = []string{"INVALID_PARAMETER_VALUE", "RESOURCE_DOES_NOT_EXIST"}
+
+//// TABLE DEFINITION
+
+func tableDatabricksJobTask(_ context.Context) *plugin.Table {
+	return &plugin.Table{
+		Name:        "databricks_job_task",
+		Description: "List the tasks defined in each job.",
+		List: &plugin.ListConfig{
+			ParentHydrate: listJobsForChild,
+			Hydrate:       listJobTasks,
+			KeyColumns:    plugin.OptionalColumns([]string{"job_id"}),
+			ParentTags:    map[string]string{"service": "jobs"},
+			Tags:          map[string]string{"service": "jobs"},
+		},
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
+			{
+				Name:        "job_id",
+				Description: "The canonical identifier of the job.",
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "job_name",
+				Description: "The name of the job.",
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "task_key",
+				Description: "A unique name for the task within its job.",
+				Transform:   transform.FromField("Task.TaskKey").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "description",
+				Description: "An optional description for the task.",
+				Transform:   transform.FromField("Task.Description").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "task_type",
+				Description: "The type of the task, e.g. notebook, spark_python, python_wheel, sql or dbt.",
+				Transform:   transform.From(jobTaskType),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source_path",
+				Description: "The notebook path, Python file, SQL file or dbt project directory the task runs.",
+				Transform:   transform.From(jobTaskSourcePath),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "source",
+				Description: "Whether the source path is in the workspace (WORKSPACE) or in the job's Git repository (GIT).",
+				Transform:   transform.From(jobTaskSource),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "existing_cluster_id",
+				Description: "The ID of an existing cluster the task runs on.",
+				Transform:   transform.FromField("Task.ExistingClusterId").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "job_cluster_key",
+				Description: "The key of the job cluster the task runs on.",
+				Transform:   transform.FromField("Task.JobClusterKey").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "compute_key",
+				Description: "The key of the compute requirements the task runs on.",
+				Transform:   transform.FromField("Task.ComputeKey").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+			{
+				Name:        "timeout_seconds",
+				Description: "The timeout applied to each run of the task, in seconds. Zero means no timeout.",
+				Transform:   transform.FromField("Task.TimeoutSeconds"),
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "max_retries",
+				Description: "The maximum number of times to retry an unsuccessful run. -1 means retry indefinitely.",
+				Transform:   transform.FromField("Task.MaxRetries"),
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "min_retry_interval_millis",
+				Description: "The minimum interval in milliseconds between a failed run and its retry.",
+				Transform:   transform.FromField("Task.MinRetryIntervalMillis"),
+				Type:        proto.ColumnType_INT,
+			},
+			{
+				Name:        "retry_on_timeout",
+				Description: "Whether to retry the task when it times out.",
+				Transform:   transform.FromField("Task.RetryOnTimeout"),
+				Type:        proto.ColumnType_BOOL,
+			},
+			{
+				Name:        "run_if",
+				Description: "The condition on its dependencies under which the task runs.",
+				Transform:   transform.FromField("Task.RunIf").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+
+			// JSON fields
+			{
+				Name:        "depends_on",
+				Description: "The task keys of the tasks this task depends on.",
+				Transform:   transform.From(jobTaskDependsOn),
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "email_notifications",
+				Description: "The email addresses notified when runs of the task begin, succeed or fail.",
+				Transform:   transform.FromField("Task.EmailNotifications"),
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "libraries",
+				Description: "The libraries installed on the cluster that runs the task.",
+				Transform:   transform.FromField("Task.Libraries"),
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "new_cluster",
+				Description: "The specification of the new cluster created for each run of the task.",
+				Transform:   transform.FromField("Task.NewCluster"),
+				Type:        proto.ColumnType_JSON,
+			},
+			{
+				Name:        "task",
+				Description: "The settings of the task for its task type, e.g. the notebook path and parameters of a notebook task.",
+				Transform:   transform.From(jobTaskSettings),
+				Type:        proto.ColumnType_JSON,
+			},
+
+			// Standard Steampipe columns
+			{
+				Name:        "title",
+				Description: "The title of the resource.",
+				Transform:   transform.FromField("Task.TaskKey").Transform(transform.NullIfZeroValue),
+				Type:        proto.ColumnType_STRING,
+			},
+		}),
+	}
+}
+
+// jobTaskInfo is a task along with the job it is defined in.
+type jobTaskInfo struct {
+	jobs.Task
+	JobId   int64
+	JobName string
+}
+
+//// LIST FUNCTION
+
+func listJobTasks(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	job, err := getJobForChild(ctx, d, h)
+	if err != nil || job.Settings == nil {
+		return nil, err
+	}
+
+	for _, task := range job.Settings.Tasks {
+		d.StreamListItem(ctx, jobTaskInfo{
+			Task:    task,
+			JobId:   job.JobId,
+			JobName: job.Settings.Name,
+		})
+
+		// Context can be cancelled due to manual cancellation or the limit has been hit
+		if d.RowsRemaining(ctx) == 0 {
+			return nil, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// listJobsForChild is the parent hydrate of the tables listing the children
+// of a job. It streams the job given by the job_id qual without listing, or
+// else every job.
+func listJobsForChild(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (interface{}, error) {
+	if d.EqualsQuals["job_id"] != nil {
+		d.StreamListItem(ctx, jobs.BaseJob{JobId: d.EqualsQuals["job_id"].GetInt64Value()})
+		return nil, nil
+	}
+	return listJobs(ctx, d, h)
+}
+
+// getJobForChild gets the full definition of the job streamed by the parent
+// hydrate, as the list API truncates long task lists. It returns an empty job
+// if the job does not exist, or the job_id qual is 0.
+func getJobForChild(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData) (jobs.Job, error) {
+	job, err := getJob(ctx, d, h)
+	if err != nil {
+		// The job given by the qual does not exist, or was deleted after it was listed
+		if shouldIgnoreErrors(jobNotFoundErrors)(ctx, d, h, err) {
+			return jobs.Job{}, nil
+		}
+		return jobs.Job{}, err
+	}
+	j, ok := job.(jobs.Job)
+	if !ok {
+		return jobs.Job{}, nil
+	}
+	return j, nil
+}
+
+//// TRANSFORM FUNCTIONS
+
+func jobTaskDependsOn(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	task := d.HydrateItem.(jobTaskInfo)
+	if len(task.DependsOn) == 0 {
+		return nil, nil
+	}
+	var taskKeys []string
+	for _, dependency := range task.DependsOn {
+		taskKeys = append(taskKeys, dependency.TaskKey)
+	}
+	return taskKeys, nil
+}
+
+func jobTaskType(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	taskType, _ := jobTaskTypeAndSettings(d.HydrateItem.(jobTaskInfo).Task)
+	if taskType == "" {
+		return nil, nil
+	}
+	return taskType, nil
+}
+
+func jobTaskSettings(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	_, settings := jobTaskTypeAndSettings(d.HydrateItem.(jobTaskInfo).Task)
+	return settings, nil
+}
+
+func jobTaskSourcePath(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	task := d.HydrateItem.(jobTaskInfo)
+	switch {
+	case task.NotebookTask != nil:
+		return task.NotebookTask.NotebookPath, nil
+	case task.SparkPythonTask != nil:
+		return task.SparkPythonTask.PythonFile, nil
+	case task.SqlTask != nil && task.SqlTask.File != nil:
+		return task.SqlTask.File.Path, nil
+	case task.DbtTask != nil && task.DbtTask.ProjectDirectory != "":
+		return task.DbtTask.ProjectDirectory, nil
+	}
+	return nil, nil
+}
+
+func jobTaskSource(_ context.Context, d *transform.TransformData) (interface{}, error) {
+	task := d.HydrateItem.(jobTaskInfo)
+	switch {
+	case task.NotebookTask != nil && task.NotebookTask.Source != "":
+		return task.NotebookTask.Source, nil
+	case task.SparkPythonTask != nil && task.SparkPythonTask.Source != "":
+		return task.SparkPythonTask.Source, nil
+	}
+	return nil, nil
+}
+
+// jobTaskTypeAndSettings returns the type of a task, named after its settings
+// field without the _task suffix, along with those settings.
+func jobTaskTypeAndSettings(task jobs.Task) (string, interface{}) {
+	switch {
+	case task.NotebookTask != nil:
+		return "notebook", task.NotebookTask
+	case task.SparkPythonTask != nil:
+		return "spark_python", task.SparkPythonTask
+	case task.PythonWheelTask != nil:
+		return "python_wheel", task.PythonWheelTask
+	case task.SparkJarTask != nil:
+		return "spark_jar", task.SparkJarTask
+	case task.SparkSubmitTask != nil:
+		return "spark_submit", task.SparkSubmitTask
+	case task.PipelineTask != nil:
+		return "pipeline", task.PipelineTask
+	case task.SqlTask != nil:
+		return "sql", task.SqlTask
+	case task.DbtTask != nil:
+		return "dbt", task.DbtTask
+	case task.ConditionTask != nil:
+		return "condition", task.ConditionTask
+	}
+	return "", nil
+}
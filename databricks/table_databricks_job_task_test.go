@@ -0,0 +1,82 @@
+package databricks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestListJobTasks(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_job_task", []string{"job_id", "job_name", "task_key", "task_type", "source_path", "source", "job_cluster_key", "existing_cluster_id", "max_retries", "timeout_seconds", "depends_on", "libraries"}, nil, 0)
+
+	if got, want := columnStrings(rows, "task_key"), []string{"ingest", "publish", "transform"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("task_key = %v, want %v", got, want)
+	}
+
+	ingest := rowWith(t, rows, "task_key", "ingest")
+	if ingest["job_id"] != int64(11) || ingest["job_name"] != "nightly-etl" || ingest["task_type"] != "notebook" ||
+		ingest["source_path"] != "/Repos/etl/ingest" || ingest["source"] != "WORKSPACE" || ingest["job_cluster_key"] != "etl" ||
+		ingest["max_retries"] != int64(2) || ingest["timeout_seconds"] != int64(3600) {
+		t.Errorf("ingest task = %v", ingest)
+	}
+
+	transform := rowWith(t, rows, "task_key", "transform")
+	if transform["task_type"] != "spark_python" || transform["source_path"] != "/Users/alice@example.com/transform.py" {
+		t.Errorf("transform task = %v", transform)
+	}
+	if got, want := transform["depends_on"], []interface{}{"ingest"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("depends_on = %v, want %v", got, want)
+	}
+	if transform["libraries"] == nil {
+		t.Errorf("libraries = nil, want the pypi library")
+	}
+
+	publish := rowWith(t, rows, "task_key", "publish")
+	if publish["task_type"] != "sql" || publish["source_path"] != "/Users/carol@example.com/publish.sql" || publish["existing_cluster_id"] != "0801-101010-abcd1234" {
+		t.Errorf("publish task = %v", publish)
+	}
+
+	// The tasks come from the full job definition
+	if got := len(fake.requestsTo("/api/2.1/jobs/get")); got != 3 {
+		t.Errorf("got %d get requests, want 3", got)
+	}
+}
+
+func TestListJobTasksJobId(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_job_task", []string{"job_id", "task_key"}, []*proto.Qual{
+		qual("job_id", "=", 13),
+	}, 0)
+
+	if got, want := columnStrings(rows, "task_key"), []string{"publish"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("task_key = %v, want %v", got, want)
+	}
+	if got := len(fake.requestsTo("/api/2.1/jobs/get")); got != 1 {
+		t.Errorf("got %d get requests, want 1", got)
+	}
+	if len(fake.requestsTo("/api/2.1/jobs/list")) != 0 {
+		t.Errorf("expected the job to be fetched by ID without listing")
+	}
+}
+
+func TestListJobTasksJobIdNotFound(t *testing.T) {
+	// A job ID of 0 is not looked up, an unknown one is not found
+	for _, jobId := range []int{0, 99} {
+		fake := newFakeDatabricks(t)
+		p := newTestPlugin(t, fake)
+
+		rows := p.query(t, "databricks_job_task", []string{"job_id", "task_key"}, []*proto.Qual{
+			qual("job_id", "=", jobId),
+		}, 0)
+
+		if len(rows) != 0 {
+			t.Errorf("job_id = %d: got %d rows, want 0", jobId, len(rows))
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package databricks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestListJobsPagination(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_job", []string{"job_id", "name"}, nil, 0)
+
+	if got, want := columnStrings(rows, "job_id"), []string{"11", "12", "13"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("job_id = %v, want %v", got, want)
+	}
+	requests := fake.requestsTo("/api/2.1/jobs/list")
+	if len(requests) != 2 {
+		t.Fatalf("got %d list requests, want 2", len(requests))
+	}
+	if got := requests[1].Query.Get("page_token"); got != "page-2" {
+		t.Errorf("page_token = %q, want %q", got, "page-2")
+	}
+}
+
+func TestListJobsLimit(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_job", []string{"job_id"}, nil, 1)
+
+	if len(rows) != 1 {
+		t.Fatalf("got %d rows, want 1", len(rows))
+	}
+	requests := fake.requestsTo("/api/2.1/jobs/list")
+	if len(requests) != 1 {
+		t.Fatalf("got %d list requests, want 1", len(requests))
+	}
+	if got := requests[0].Query.Get("limit"); got != "1" {
+		t.Errorf("limit = %q, want %q", got, "1")
+	}
+}
+
+func TestListJobsNamePushdown(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_job", []string{"job_id", "name"}, []*proto.Qual{
+		qual("name", "=", "nightly-etl"),
+	}, 0)
+
+	if got, want := columnStrings(rows, "job_id"), []string{"11", "13"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("job_id = %v, want %v", got, want)
+	}
+	for _, request := range fake.requestsTo("/api/2.1/jobs/list") {
+		if got := request.Query.Get("name"); got != "nightly-etl" {
+			t.Errorf("name = %q, want %q", got, "nightly-etl")
+		}
+	}
+}
+
+func TestGetJob(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_job", []string{"job_id", "name", "creator_user_name"}, []*proto.Qual{
+		qual("job_id", "=", 12),
+	}, 0)
+
+	if len(rows) != 1 || rows[0]["name"] != "hourly-report" || rows[0]["creator_user_name"] != "bob@example.com" {
+		t.Fatalf("got %v, want hourly-report created by bob@example.com", rows)
+	}
+}
+
+func TestGetJobNotFound(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_job", []string{"job_id"}, []*proto.Qual{
+		qual("job_id", "=", 99),
+	}, 0)
+
+	if len(rows) != 0 {
+		t.Errorf("got %d rows, want 0", len(rows))
+	}
+}
+
+func TestJobPermissionsPermissionDenied(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	fake.permissionDenied["12"] = true
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_job", []string{"job_id", "job_permissions"}, nil, 0)
+
+	if len(rows) != 3 {
+		t.Fatalf("got %d rows, want 3", len(rows))
+	}
+	if got := rowWith(t, rows, "job_id", "12")["job_permissions"]; got != nil {
+		t.Errorf("job_permissions = %v, want null", got)
+	}
+	if got := rowWith(t, rows, "job_id", "11")["job_permissions"]; got == nil {
+		t.Errorf("job_permissions = null, want the job permissions")
+	}
+}
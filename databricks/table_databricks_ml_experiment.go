@@ -18,12 +18,15 @@ func tableDatabricksMLExperiment(_ context.Context) *plugin.Table {
 		List: &plugin.ListConfig{
 			Hydrate:    listMLExperiments,
 			KeyColumns: plugin.OptionalColumns([]string{"lifecycle_stage"}),
+			Tags:       map[string]string{"service": "ml"},
 		},
 		Get: &plugin.GetConfig{
 			KeyColumns: plugin.SingleColumn("experiment_id"),
 			Hydrate:    getMLExperiment,
+			Tags:       map[string]string{"service": "ml"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "experiment_id",
 				Description: "Unique identifier for the experiment.",
@@ -17,12 +17,15 @@ func tableDatabricksMLModel(_ context.Context) *plugin.Table {
 		Description: "List all available registered models.",
 		List: &plugin.ListConfig{
 			Hydrate: listMLModels,
+			Tags:    map[string]string{"service": "ml"},
 		},
 		Get: &plugin.GetConfig{
 			KeyColumns: plugin.SingleColumn("name"),
 			Hydrate:    getMLModel,
+			Tags:       map[string]string{"service": "ml"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "name",
 				Description: "Unique name for the model.",
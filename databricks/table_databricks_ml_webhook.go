@@ -18,8 +18,10 @@ func tableDatabricksMLWebhook(_ context.Context) *plugin.Table {
 		List: &plugin.ListConfig{
 			Hydrate:    listMLWebhooks,
 			KeyColumns: plugin.OptionalColumns([]string{"events", "model_name"}),
+			Tags:       map[string]string{"service": "ml"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "id",
 				Description: "The ID of the webhook.",
@@ -18,12 +18,24 @@ func tableDatabricksPipeline(_ context.Context) *plugin.Table {
 		Description: "List pipelines defined in the Delta Live Tables system.",
 		List: &plugin.ListConfig{
 			Hydrate: listPipelines,
+			Tags:    map[string]string{"service": "pipelines"},
 		},
 		Get: &plugin.GetConfig{
 			KeyColumns: plugin.SingleColumn("pipeline_id"),
 			Hydrate:    getPipeline,
+			Tags:       map[string]string{"service": "pipelines"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getPipelinePermissions,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "pipelines"},
+			},
+		},
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "pipeline_id",
 				Description: "Unique identifier of pipeline.",
@@ -19,8 +19,10 @@ func tableDatabricksPipelineEvent(_ context.Context) *plugin.Table {
 			ParentHydrate: listPipelines,
 			Hydrate:       listPipelineEvents,
 			KeyColumns:    plugin.OptionalColumns([]string{"pipeline_id"}),
+			Tags:          map[string]string{"service": "pipelines"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "id",
 				Description: "A time-based, globally unique id.",
@@ -19,12 +19,15 @@ func tableDatabricksPipelineUpdate(_ context.Context) *plugin.Table {
 			ParentHydrate: listPipelines,
 			Hydrate:       listPipelineUpdates,
 			KeyColumns:    plugin.OptionalColumns([]string{"pipeline_id"}),
+			Tags:          map[string]string{"service": "pipelines"},
 		},
 		Get: &plugin.GetConfig{
 			KeyColumns: plugin.AllColumns([]string{"pipeline_id", "update_id"}),
 			Hydrate:    getPipelineUpdate,
+			Tags:       map[string]string{"service": "pipelines"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "update_id",
 				Description: "Unique identifier of the update.",
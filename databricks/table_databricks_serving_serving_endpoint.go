@@ -18,12 +18,24 @@ func tableDatabricksServingServingEndpoint(_ context.Context) *plugin.Table {
 		Description: "List all serving endpoints.",
 		List: &plugin.ListConfig{
 			Hydrate: listServingServingEndpoints,
+			Tags:    map[string]string{"service": "ml"},
 		},
 		Get: &plugin.GetConfig{
 			KeyColumns: plugin.SingleColumn("name"),
 			Hydrate:    getServingServingEndpoint,
+			Tags:       map[string]string{"service": "ml"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getServingServingEndpointPermissions,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "ml"},
+			},
+		},
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "id",
 				Description: "System-generated ID of the endpoint.",
@@ -16,12 +16,15 @@ func tableDatabricksSettingsIpAccessList(_ context.Context) *plugin.Table {
 		Description: "Gets all IP access lists for the specified workspace.",
 		List: &plugin.ListConfig{
 			Hydrate: listSettingsIpAccessLists,
+			Tags:    map[string]string{"service": "settings"},
 		},
 		Get: &plugin.GetConfig{
 			KeyColumns: plugin.SingleColumn("list_id"),
 			Hydrate:    getSettingsIpAccessList,
+			Tags:       map[string]string{"service": "settings"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "list_id",
 				Description: "Universally unique identifier (UUID) of the IP access list.",
@@ -14,8 +14,10 @@ func tableDatabricksSettingsToken(_ context.Context) *plugin.Table {
 		Description: "List all the valid tokens for a user-workspace pair.",
 		List: &plugin.ListConfig{
 			Hydrate: listSettingsToken,
+			Tags:    map[string]string{"service": "settings"},
 		},
-		Columns: getTokenInfoColumns(),
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns:           getTokenInfoColumns(),
 	}
 }
 
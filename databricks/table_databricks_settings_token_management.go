@@ -18,17 +18,24 @@ func tableDatabricksSettingsTokenManagement(_ context.Context) *plugin.Table {
 		List: &plugin.ListConfig{
 			Hydrate:    listSettingsTokenManagement,
 			KeyColumns: plugin.OptionalColumns([]string{"created_by_id", "created_by_username"}),
+			// Only workspace admins can list these, other users get an empty table
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+			},
+			Tags: map[string]string{"service": "settings"},
 		},
 		Get: &plugin.GetConfig{
 			KeyColumns: plugin.SingleColumn("token_id"),
 			Hydrate:    getSettingsTokenManagement,
+			Tags:       map[string]string{"service": "settings"},
 		},
-		Columns: getTokenInfoColumns(),
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns:           getTokenInfoColumns(),
 	}
 }
 
 func getTokenInfoColumns() []*plugin.Column {
-	return databricksAccountColumns([]*plugin.Column{
+	return databricksWorkspaceColumns([]*plugin.Column{
 		{
 			Name:        "token_id",
 			Description: "ID of the token.",
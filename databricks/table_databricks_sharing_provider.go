@@ -16,15 +16,26 @@ func tableDatabricksSharingProvider(_ context.Context) *plugin.Table {
 		Name:        "databricks_sharing_provider",
 		Description: "Gets an array of available authentication providers.",
 		List: &plugin.ListConfig{
-			Hydrate:           listSharingProviders,
-			ShouldIgnoreError: isNotFoundError([]string{"INVALID_PARAMETER_VALUE"}),
-			KeyColumns:        plugin.OptionalColumns([]string{"data_provider_global_metastore_id"}),
+			Hydrate: listSharingProviders,
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"INVALID_PARAMETER_VALUE"}),
+			},
+			KeyColumns: plugin.OptionalColumns([]string{"data_provider_global_metastore_id"}),
+			Tags:       map[string]string{"service": "unity_catalog"},
 		},
 		Get: &plugin.GetConfig{
 			KeyColumns: plugin.AnyColumn([]string{"name"}),
 			Hydrate:    getSharingProvider,
+			Tags:       map[string]string{"service": "unity_catalog"},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getSharingProviderShares,
+				Tags: map[string]string{"service": "unity_catalog"},
+			},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "name",
 				Description: "Name of the provider.",
@@ -16,15 +16,29 @@ func tableDatabricksSharingRecipient(_ context.Context) *plugin.Table {
 		Name:        "databricks_sharing_recipient",
 		Description: "Gets an array of all share recipients within the current metastore.",
 		List: &plugin.ListConfig{
-			Hydrate:           listSharingRecipients,
-			ShouldIgnoreError: isNotFoundError([]string{"INVALID_PARAMETER_VALUE"}),
-			KeyColumns:        plugin.OptionalColumns([]string{"data_recipient_global_metastore_id"}),
+			Hydrate: listSharingRecipients,
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"INVALID_PARAMETER_VALUE"}),
+			},
+			KeyColumns: plugin.OptionalColumns([]string{"data_recipient_global_metastore_id"}),
+			Tags:       map[string]string{"service": "unity_catalog"},
 		},
 		Get: &plugin.GetConfig{
 			KeyColumns: plugin.AnyColumn([]string{"name"}),
 			Hydrate:    getSharingRecipient,
+			Tags:       map[string]string{"service": "unity_catalog"},
+		},
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getSharingRecipientPermissions,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "unity_catalog"},
+			},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "name",
 				Description: "Name of the recipient.",
@@ -16,13 +16,18 @@ func tableDatabricksSQLAlert(_ context.Context) *plugin.Table {
 		Description: "Gets a list of alerts.",
 		List: &plugin.ListConfig{
 			Hydrate: listSQLAlerts,
+			Tags:    map[string]string{"service": "sql"},
 		},
 		Get: &plugin.GetConfig{
-			KeyColumns:        plugin.AnyColumn([]string{"id"}),
-			ShouldIgnoreError: isNotFoundError([]string{"400"}),
-			Hydrate:           getSQLAlert,
+			KeyColumns: plugin.AnyColumn([]string{"id"}),
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"400"}),
+			},
+			Hydrate: getSQLAlert,
+			Tags:    map[string]string{"service": "sql"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "id",
 				Description: "Databricks alert ID.",
@@ -18,8 +18,10 @@ func tableDatabricksSQLDashboard(_ context.Context) *plugin.Table {
 		List: &plugin.ListConfig{
 			KeyColumns: plugin.OptionalColumns([]string{"name"}),
 			Hydrate:    listSQLDashboards,
+			Tags:       map[string]string{"service": "sql"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "id",
 				Description: "Databricks dashboard ID.",
@@ -140,16 +142,12 @@ func listSQLDashboards(ctx context.Context, d *plugin.QueryData, h *plugin.Hydra
 		return nil, err
 	}
 
-	filterQuals := []filterQualMap{
-		{"name", "Name", "string"},
-	}
-
-	filter := buildQueryFilterFromQuals(filterQuals, d.Quals)
-
+	// The name is passed as a full text search term, which matches a superset
+	// of the dashboards with that name
 	request := sql.ListDashboardsRequest{
 		PageSize: int(maxLimit),
 		Page:     1,
-		Q:        filter,
+		Q:        d.EqualsQualString("name"),
 	}
 
 	count := 0
@@ -16,8 +16,10 @@ func tableDatabricksSQLDataSource(_ context.Context) *plugin.Table {
 		Description: "Retrieves a full list of SQL warehouses available in this workspace.",
 		List: &plugin.ListConfig{
 			Hydrate: listSQLDataSources,
+			Tags:    map[string]string{"service": "sql"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "id",
 				Description: "The unique identifier for this data source / SQL warehouse.",
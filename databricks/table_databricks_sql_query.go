@@ -17,12 +17,15 @@ func tableDatabricksSQLQuery(_ context.Context) *plugin.Table {
 		Description: "Gets a list of queries.",
 		List: &plugin.ListConfig{
 			Hydrate: listSQLQueries,
+			Tags:    map[string]string{"service": "sql"},
 		},
 		Get: &plugin.GetConfig{
 			KeyColumns: plugin.AnyColumn([]string{"id"}),
 			Hydrate:    getSQLQuery,
+			Tags:       map[string]string{"service": "sql"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "id",
 				Description: "Databricks query ID.",
@@ -2,10 +2,18 @@ package databricks
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
 
+	"github.com/databricks/databricks-sdk-go"
+	"github.com/databricks/databricks-sdk-go/service/iam"
 	"github.com/databricks/databricks-sdk-go/service/sql"
 	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/transform"
 )
 
 //// TABLE DEFINITION
@@ -15,10 +23,19 @@ func tableDatabricksSQLQueryHistory(_ context.Context) *plugin.Table {
 		Name:        "databricks_sql_query_history",
 		Description: "List the history of queries through SQL warehouses.",
 		List: &plugin.ListConfig{
-			Hydrate:    listSQLQueryHistory,
-			KeyColumns: plugin.OptionalColumns([]string{"warehouse_id", "user_id", "status"}),
+			Hydrate: listSQLQueryHistory,
+			KeyColumns: plugin.KeyColumnSlice{
+				{Name: "warehouse_id", Require: plugin.Optional},
+				{Name: "user_id", Require: plugin.Optional},
+				{Name: "user_name", Require: plugin.Optional},
+				{Name: "status", Require: plugin.Optional},
+				{Name: "start_time", Require: plugin.Optional, Operators: []string{"=", ">", ">=", "<", "<="}},
+				{Name: "include_metrics", Require: plugin.Optional},
+			},
+			Tags: map[string]string{"service": "sql"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "query_id",
 				Description: "Databricks query ID.",
@@ -89,6 +106,18 @@ func tableDatabricksSQLQueryHistory(_ context.Context) *plugin.Table {
 				Description: "The time the query started.",
 				Type:        proto.ColumnType_INT,
 			},
+			{
+				Name:        "start_time",
+				Description: "The time the query started.",
+				Transform:   transform.FromField("QueryStartTimeMs").Transform(transform.UnixMsToTimestamp),
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
+			{
+				Name:        "end_time",
+				Description: "The time the query ended.",
+				Transform:   transform.FromField("QueryEndTimeMs").Transform(transform.UnixMsToTimestamp),
+				Type:        proto.ColumnType_TIMESTAMP,
+			},
 			{
 				Name:        "query_text",
 				Description: "The text of the query.",
@@ -128,9 +157,17 @@ func tableDatabricksSQLQueryHistory(_ context.Context) *plugin.Table {
 			},
 			{
 				Name:        "metrics",
-				Description: "Metrics about query execution.",
+				Description: "Metrics about query execution. Only returned when the metrics column is selected or include_metrics is true.",
 				Type:        proto.ColumnType_JSON,
 			},
+
+			// Input parameters
+			{
+				Name:        "include_metrics",
+				Description: "Whether to return metrics about query execution. Defaults to true when the metrics column is selected.",
+				Transform:   transform.FromQual("include_metrics"),
+				Type:        proto.ColumnType_BOOL,
+			},
 		}),
 	}
 }
@@ -150,37 +187,54 @@ func listSQLQueryHistory(ctx context.Context, d *plugin.QueryData, h *plugin.Hyd
 	}
 
 	request := sql.ListQueryHistoryRequest{
-		MaxResults: maxLimit,
-		FilterBy:   &sql.QueryFilter{},
+		MaxResults:     maxLimit,
+		FilterBy:       &sql.QueryFilter{},
+		IncludeMetrics: slices.Contains(d.QueryContext.Columns, "metrics"),
 	}
-	if d.EqualsQualString("warehouse_id") != "" {
-		if request.FilterBy.WarehouseIds == nil {
-			request.FilterBy.WarehouseIds = make([]string, 0)
-		}
-		request.FilterBy.WarehouseIds = append(request.FilterBy.WarehouseIds, d.EqualsQualString("warehouse_id"))
+	if d.EqualsQuals["include_metrics"] != nil {
+		request.IncludeMetrics = d.EqualsQuals["include_metrics"].GetBoolValue()
 	}
-	if d.EqualsQuals["user_id"] != nil {
-		if request.FilterBy.UserIds == nil {
-			request.FilterBy.UserIds = make([]int, 0)
-		}
-		request.FilterBy.UserIds = append(request.FilterBy.UserIds, int(d.EqualsQuals["user_id"].GetInt64Value()))
+	for _, value := range qualValues(d.Quals["warehouse_id"]) {
+		request.FilterBy.WarehouseIds = append(request.FilterBy.WarehouseIds, value.GetStringValue())
+	}
+	for _, value := range qualValues(d.Quals["user_id"]) {
+		request.FilterBy.UserIds = append(request.FilterBy.UserIds, int(value.GetInt64Value()))
 	}
-	if d.EqualsQualString("status") != "" {
-		if request.FilterBy.WarehouseIds == nil {
-			request.FilterBy.Statuses = make([]sql.QueryStatus, 0)
+	for _, value := range qualValues(d.Quals["status"]) {
+		request.FilterBy.Statuses = append(request.FilterBy.Statuses, sql.QueryStatus(value.GetStringValue()))
+	}
+	request.FilterBy.QueryStartTimeRange = queryStartTimeRange(d.Quals["start_time"])
+
+	// The API only filters on user IDs, so look up the IDs of the user names
+	if d.Quals["user_name"] != nil && len(request.FilterBy.UserIds) == 0 {
+		client, err := getWorkspaceClient(ctx, d)
+		if err != nil {
+			logger.Error("databricks_sql_query_history.listSQLQueryHistory", "connection_error", err)
+			return nil, err
+		}
+		userIds, err := queryHistoryUserIds(ctx, client, d.Quals)
+		if err != nil {
+			logger.Error("databricks_sql_query_history.listSQLQueryHistory", "user_lookup_error", err)
+			return nil, err
 		}
-		request.FilterBy.Statuses = append(request.FilterBy.Statuses, sql.QueryStatus(d.EqualsQualString("status")))
+		// None of the users exist, so none of their queries do either
+		if len(userIds) == 0 {
+			return nil, nil
+		}
+		request.FilterBy.UserIds = userIds
 	}
 
 	// Create client
-	client, err := getWorkspaceClient(ctx, d)
+	client, err := getWorkspaceAPIClient(ctx, d)
 	if err != nil {
 		logger.Error("databricks_sql_query_history.listSQLQueryHistory", "connection_error", err)
 		return nil, err
 	}
 
 	for {
-		response, err := client.QueryHistory.Impl().List(ctx, request)
+		var response sql.ListQueriesResponse
+		path := "/api/2.0/sql/history/queries?" + queryHistoryParams(request).Encode()
+		err := client.Do(ctx, http.MethodGet, path, nil, &response)
 		if err != nil {
 			logger.Error("databricks_sql_query_history.listSQLQueryHistory", "api_error", err)
 			return nil, err
@@ -202,3 +256,96 @@ func listSQLQueryHistory(ctx context.Context, d *plugin.QueryData, h *plugin.Hyd
 		}
 	}
 }
+
+// queryStartTimeRange converts the start_time quals into the time range
+// filter. The API does not document whether the bounds are inclusive, so
+// they are widened by a millisecond and Postgres filters out the extra rows.
+func queryStartTimeRange(startTimeQuals *plugin.KeyColumnQuals) *sql.TimeRange {
+	if startTimeQuals == nil {
+		return nil
+	}
+	timeRange := &sql.TimeRange{}
+	for _, q := range startTimeQuals.Quals {
+		timestamp := q.Value.GetTimestampValue()
+		if timestamp == nil {
+			continue
+		}
+		ms := int(timestamp.AsTime().UnixMilli())
+		if q.Operator == "=" || q.Operator == ">" || q.Operator == ">=" {
+			timeRange.StartTimeMs = max(timeRange.StartTimeMs, ms-1)
+		}
+		if q.Operator == "=" || q.Operator == "<" || q.Operator == "<=" {
+			if timeRange.EndTimeMs == 0 || ms+1 < timeRange.EndTimeMs {
+				timeRange.EndTimeMs = ms + 1
+			}
+		}
+	}
+	if timeRange.StartTimeMs == 0 && timeRange.EndTimeMs == 0 {
+		return nil
+	}
+	return timeRange
+}
+
+// queryHistoryUserIds looks up the IDs of the users in the user_name quals.
+func queryHistoryUserIds(ctx context.Context, client *databricks.WorkspaceClient, keyColumnQuals plugin.KeyColumnQualMap) ([]int, error) {
+	filter := buildSCIMFilter([]scimFilterAttribute{{"user_name", "userName", proto.ColumnType_STRING}}, keyColumnQuals)
+	users, err := client.Users.ListAll(ctx, iam.ListUsersRequest{
+		Filter:     filter,
+		Attributes: "id",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var userIds []int
+	for _, user := range users {
+		id, err := strconv.Atoi(user.Id)
+		if err != nil {
+			return nil, fmt.Errorf("unexpected user ID %q: %w", user.Id, err)
+		}
+		userIds = append(userIds, id)
+	}
+	return userIds, nil
+}
+
+// queryHistoryParams encodes the request as the query parameters of the API,
+// e.g. filter_by.user_ids=1&filter_by.user_ids=2. The list is requested with
+// client.Do rather than the SDK's QueryHistory.List, as the SDK encodes the
+// nested filter as filter_by[UserIds] and
+// filter_by[QueryStartTimeRange][StartTimeMs], which the API ignores. Without
+// these parameters the IN-list quals on user, warehouse and status and the
+// range quals on start_time would not be pushed down, and the whole query
+// history would be listed instead.
+func queryHistoryParams(request sql.ListQueryHistoryRequest) url.Values {
+	params := url.Values{}
+	params.Set("max_results", strconv.Itoa(request.MaxResults))
+	if request.IncludeMetrics {
+		params.Set("include_metrics", "true")
+	}
+	if request.PageToken != "" {
+		params.Set("page_token", request.PageToken)
+	}
+
+	filter := request.FilterBy
+	if filter == nil {
+		return params
+	}
+	for _, id := range filter.WarehouseIds {
+		params.Add("filter_by.warehouse_ids", id)
+	}
+	for _, id := range filter.UserIds {
+		params.Add("filter_by.user_ids", strconv.Itoa(id))
+	}
+	for _, status := range filter.Statuses {
+		params.Add("filter_by.statuses", string(status))
+	}
+	if timeRange := filter.QueryStartTimeRange; timeRange != nil {
+		if timeRange.StartTimeMs > 0 {
+			params.Set("filter_by.query_start_time_range.start_time_ms", strconv.Itoa(timeRange.StartTimeMs))
+		}
+		if timeRange.EndTimeMs > 0 {
+			params.Set("filter_by.query_start_time_range.end_time_ms", strconv.Itoa(timeRange.EndTimeMs))
+		}
+	}
+	return params
+}
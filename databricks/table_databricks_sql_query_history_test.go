@@ -0,0 +1,109 @@
+package databricks
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestListSQLQueryHistoryFilterPushdown(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_sql_query_history", []string{"query_id", "status"}, []*proto.Qual{
+		qual("warehouse_id", "=", []string{"a1b2c3d4e5f6a7b8", "f0e1d2c3b4a59687"}),
+		qual("status", "=", []string{"FAILED", "CANCELED"}),
+		qual("start_time", ">=", time.UnixMilli(1700086400000)),
+	}, 0)
+
+	if got, want := columnStrings(rows, "query_id"), []string{"q-2", "q-3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("query_id = %v, want %v", got, want)
+	}
+	requests := fake.requestsTo("/api/2.0/sql/history/queries")
+	if len(requests) != 1 {
+		t.Fatalf("got %d list requests, want 1", len(requests))
+	}
+	query := requests[0].Query
+	if got, want := query["filter_by.warehouse_ids"], []string{"a1b2c3d4e5f6a7b8", "f0e1d2c3b4a59687"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("filter_by.warehouse_ids = %v, want %v", got, want)
+	}
+	if got, want := query["filter_by.statuses"], []string{"FAILED", "CANCELED"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("filter_by.statuses = %v, want %v", got, want)
+	}
+	if got, want := query.Get("filter_by.query_start_time_range.start_time_ms"), "1700086399999"; got != want {
+		t.Errorf("filter_by.query_start_time_range.start_time_ms = %q, want %q", got, want)
+	}
+}
+
+func TestListSQLQueryHistoryUserName(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_sql_query_history", []string{"query_id", "user_name"}, []*proto.Qual{
+		qual("user_name", "=", "alice@example.com"),
+	}, 0)
+
+	if got, want := columnStrings(rows, "query_id"), []string{"q-1", "q-3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("query_id = %v, want %v", got, want)
+	}
+	if got := fake.requestsTo("/api/2.0/preview/scim/v2/Users")[0].Query.Get("filter"); got != `userName eq "alice@example.com"` {
+		t.Errorf("filter = %q, want %q", got, `userName eq "alice@example.com"`)
+	}
+	for _, request := range fake.requestsTo("/api/2.0/sql/history/queries") {
+		if got, want := request.Query["filter_by.user_ids"], []string{"1001"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("filter_by.user_ids = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestListSQLQueryHistoryUnknownUserName(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_sql_query_history", []string{"query_id"}, []*proto.Qual{
+		qual("user_name", "=", "nobody@example.com"),
+	}, 0)
+
+	if len(rows) != 0 {
+		t.Errorf("got %d rows, want 0", len(rows))
+	}
+	if requests := fake.requestsTo("/api/2.0/sql/history/queries"); len(requests) != 0 {
+		t.Errorf("got %d list requests, want 0", len(requests))
+	}
+}
+
+func TestListSQLQueryHistoryMetrics(t *testing.T) {
+	tests := []struct {
+		name        string
+		columns     []string
+		quals       []*proto.Qual
+		wantMetrics bool
+	}{
+		{"metrics not selected", []string{"query_id"}, nil, false},
+		{"metrics selected", []string{"query_id", "metrics"}, nil, true},
+		{"metrics disabled", []string{"query_id", "metrics"}, []*proto.Qual{qual("include_metrics", "=", false)}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeDatabricks(t)
+			p := newTestPlugin(t, fake)
+
+			rows := p.query(t, "databricks_sql_query_history", tt.columns, tt.quals, 0)
+
+			if len(rows) != 3 {
+				t.Fatalf("got %d rows, want 3", len(rows))
+			}
+			for _, request := range fake.requestsTo("/api/2.0/sql/history/queries") {
+				if got := request.Query.Get("include_metrics") == "true"; got != tt.wantMetrics {
+					t.Errorf("include_metrics = %v, want %v", got, tt.wantMetrics)
+				}
+			}
+			if tt.wantMetrics && rows[0]["metrics"] == nil {
+				t.Errorf("metrics = nil, want metrics")
+			}
+		})
+	}
+}
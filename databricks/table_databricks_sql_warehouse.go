@@ -18,12 +18,24 @@ func tableDatabricksSQLWarehouse(_ context.Context) *plugin.Table {
 		Description: "Gets a list of warehouses.",
 		List: &plugin.ListConfig{
 			Hydrate: listSQLWarehouses,
+			Tags:    map[string]string{"service": "sql"},
 		},
 		Get: &plugin.GetConfig{
 			KeyColumns: plugin.AnyColumn([]string{"id"}),
 			Hydrate:    getSQLWarehouse,
+			Tags:       map[string]string{"service": "sql"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getSQLWarehousePermissions,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "sql"},
+			},
+		},
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "id",
 				Description: "Unique identifier for warehouse.",
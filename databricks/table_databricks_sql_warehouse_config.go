@@ -15,8 +15,10 @@ func tableDatabricksSQLWarehouseConfig(_ context.Context) *plugin.Table {
 		Description: "Gets the workspace level configuration that is shared by all SQL warehouses in a workspace.",
 		List: &plugin.ListConfig{
 			Hydrate: getSQLWarehouseConfig,
+			Tags:    map[string]string{"service": "sql"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "google_service_account",
 				Description: "Google Service Account used to pass to cluster to access Google Cloud Storage.",
@@ -0,0 +1,31 @@
+package databricks
+
+import (
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestListSQLWarehouses(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_sql_warehouse", []string{"id", "name", "cluster_size"}, nil, 0)
+
+	if len(rows) != 1 || rows[0]["name"] != "Starter Warehouse" || rows[0]["cluster_size"] != "Small" {
+		t.Fatalf("got %v, want the Starter Warehouse", rows)
+	}
+}
+
+func TestGetSQLWarehouseNotFound(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_sql_warehouse", []string{"id"}, []*proto.Qual{
+		qual("id", "=", "0000000000000000"),
+	}, 0)
+
+	if len(rows) != 0 {
+		t.Errorf("got %d rows, want 0", len(rows))
+	}
+}
@@ -16,11 +16,15 @@ func tableDatabricksWorkspace(_ context.Context) *plugin.Table {
 		Name:        "databricks_workspace",
 		Description: "List all secret workspaces available in the workspace.",
 		List: &plugin.ListConfig{
-			Hydrate:           listWorkspaces,
-			ShouldIgnoreError: isNotFoundError([]string{"RESOURCE_DOES_NOT_EXIST"}),
-			KeyColumns:        plugin.OptionalColumns([]string{"path"}),
+			Hydrate: listWorkspaces,
+			IgnoreConfig: &plugin.IgnoreConfig{
+				ShouldIgnoreErrorFunc: shouldIgnoreErrors([]string{"RESOURCE_DOES_NOT_EXIST"}),
+			},
+			KeyColumns: plugin.OptionalColumns([]string{"path"}),
+			Tags:       map[string]string{"service": "workspace"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "object_id",
 				Description: "Unique identifier for the object.",
@@ -16,12 +16,15 @@ func tableDatabricksWorkspaceGitCredential(_ context.Context) *plugin.Table {
 		Description: "Lists the calling user's Git credentials.",
 		List: &plugin.ListConfig{
 			Hydrate: listWorkspaceGitCredentials,
+			Tags:    map[string]string{"service": "workspace"},
 		},
 		Get: &plugin.GetConfig{
 			KeyColumns: plugin.AnyColumn([]string{"credential_id", "git_provider"}),
 			Hydrate:    getWorkspaceGitCredential,
+			Tags:       map[string]string{"service": "workspace"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "credential_id",
 				Description: "ID of the credential object in the workspace.",
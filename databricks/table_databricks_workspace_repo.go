@@ -18,12 +18,15 @@ func tableDatabricksWorkspaceRepo(_ context.Context) *plugin.Table {
 		List: &plugin.ListConfig{
 			Hydrate:    listWorkspaceRepos,
 			KeyColumns: plugin.OptionalColumns([]string{"path"}),
+			Tags:       map[string]string{"service": "workspace"},
 		},
 		Get: &plugin.GetConfig{
 			KeyColumns: plugin.AnyColumn([]string{"id"}),
 			Hydrate:    getWorkspaceRepo,
+			Tags:       map[string]string{"service": "workspace"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "id",
 				Description: "ID of the repo object in the workspace.",
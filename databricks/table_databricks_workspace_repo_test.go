@@ -0,0 +1,52 @@
+package databricks
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
+)
+
+func TestListWorkspaceReposPagination(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_workspace_repo", []string{"id", "path"}, nil, 0)
+
+	if got, want := columnStrings(rows, "id"), []string{"201", "202", "203"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("id = %v, want %v", got, want)
+	}
+	if got := len(fake.requestsTo("/api/2.0/repos")); got != 2 {
+		t.Errorf("got %d list requests, want 2", got)
+	}
+}
+
+func TestListWorkspaceReposPathPushdown(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_workspace_repo", []string{"id", "path"}, []*proto.Qual{
+		qual("path", "=", "/Repos/bob@example.com"),
+	}, 0)
+
+	if got, want := columnStrings(rows, "id"), []string{"203"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("id = %v, want %v", got, want)
+	}
+	requests := fake.requestsTo("/api/2.0/repos")
+	if len(requests) != 1 || requests[0].Query.Get("path_prefix") != "/Repos/bob@example.com" {
+		t.Errorf("expected a single request with the path prefix, got %v", requests)
+	}
+}
+
+func TestGetWorkspaceRepoNotFound(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	p := newTestPlugin(t, fake)
+
+	rows := p.query(t, "databricks_workspace_repo", []string{"id"}, []*proto.Qual{
+		qual("id", "=", 999),
+	}, 0)
+
+	if len(rows) != 0 {
+		t.Errorf("got %d rows, want 0", len(rows))
+	}
+}
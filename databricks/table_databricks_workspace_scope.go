@@ -17,8 +17,19 @@ func tableDatabricksWorkspaceScope(_ context.Context) *plugin.Table {
 		Description: "List all secret scopes available in the workspace.",
 		List: &plugin.ListConfig{
 			Hydrate: listWorkspaceScopes,
+			Tags:    map[string]string{"service": "workspace"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		HydrateConfig: []plugin.HydrateConfig{
+			{
+				Func: getWorkspaceScopeAcls,
+				IgnoreConfig: &plugin.IgnoreConfig{
+					ShouldIgnoreErrorFunc: shouldIgnoreErrors(permissionDeniedErrors),
+				},
+				Tags: map[string]string{"service": "workspace"},
+			},
+		},
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "name",
 				Description: "A unique name to identify the secret scope.",
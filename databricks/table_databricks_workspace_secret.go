@@ -19,8 +19,11 @@ func tableDatabricksWorkspaceSecret(_ context.Context) *plugin.Table {
 			ParentHydrate: listWorkspaceScopes,
 			Hydrate:       listWorkspaceSecrets,
 			KeyColumns:    plugin.OptionalColumns([]string{"scope_name"}),
+			ParentTags:    map[string]string{"service": "workspace"},
+			Tags:          map[string]string{"service": "workspace"},
 		},
-		Columns: databricksAccountColumns([]*plugin.Column{
+		GetMatrixItemFunc: workspaceMatrix,
+		Columns: databricksWorkspaceColumns([]*plugin.Column{
 			{
 				Name:        "scope_name",
 				Description: "The name of the secret scope.",
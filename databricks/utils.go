@@ -2,11 +2,25 @@ package databricks
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"reflect"
+	"runtime"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/turbot/steampipe-plugin-sdk/v5/grpc/proto"
 	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin/quals"
 )
 
 func isNotFoundError(notFoundErrors []string) plugin.ErrorPredicate {
@@ -25,69 +39,321 @@ func isNotFoundError(notFoundErrors []string) plugin.ErrorPredicate {
 	}
 }
 
-func shouldRetryError(retryErrors []string) plugin.ErrorPredicateWithContext {
+// Error codes returned when the caller lacks the privileges to read a
+// resource, e.g. the permissions of a cluster which is not owned by them
+var permissionDeniedErrors = []string{"PERMISSION_DENIED", "403"}
+
+// Error codes skipped when walking the securables of a Unity Catalog
+// metastore, as securables can be dropped while they are walked and the
+// caller may not be allowed to read all of them
+var catalogWalkIgnoreErrors = append([]string{"DOES_NOT_EXIST", "404"}, permissionDeniedErrors...)
+
+// shouldIgnoreErrors returns a predicate which ignores errors matching any of
+// the given error codes or HTTP status codes, as well as the error codes in
+// the ignore_error_codes connection argument. The affected rows or columns
+// come back empty, so the reason is logged.
+func shouldIgnoreErrors(ignoreErrors []string) plugin.ErrorPredicateWithContext {
 	return func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, err error) bool {
-		switch err := err.(type) {
-		case *apierr.APIError:
-			for _, msg := range retryErrors {
-				if strings.Contains(err.ErrorCode, msg) {
-					plugin.Logger(ctx).Error("databricks_errors.shouldRetryError", "rate_limit_error", err)
-					return true
-				} else if strings.Contains(strconv.Itoa(err.StatusCode), msg) {
-					plugin.Logger(ctx).Error("databricks_errors.shouldRetryError", "rate_limit_error", err)
-					return true
-				}
+		config := GetConfig(d.Connection)
+		codes := append(slices.Clone(ignoreErrors), config.IgnoreErrorCodes...)
+		if !isNotFoundError(codes)(err) {
+			return false
+		}
+		plugin.Logger(ctx).Info("shouldIgnoreErrors", "connection_name", d.Connection.Name, "table", d.Table.Name, "ignored_error", err)
+		return true
+	}
+}
+
+// Retry policy defaults, each of which can be overridden in the connection config
+const (
+	defaultMaxRetries            = 10
+	defaultMaxErrorRetryAttempts = 5
+	defaultMinRetryDelay         = 100 * time.Millisecond
+	maxRetryDelay                = 30 * time.Second
+	// Upper bound on retries per hydrate call, the connection config limits
+	// are enforced by shouldRetryError within this bound, so max_retries and
+	// max_error_retry_attempts above it are capped
+	maxRetryAttemptsLimit = 100
+)
+
+type retryClass int
+
+const (
+	retryClassNone retryClass = iota
+	// The request was throttled by the API, e.g. 429 or REQUEST_LIMIT_EXCEEDED
+	retryClassThrottled
+	// The request failed with a transient server or network error
+	retryClassTransient
+)
+
+var throttledErrorCodes = []string{"TOO_MANY_REQUESTS", "REQUEST_LIMIT_EXCEEDED", "RESOURCE_EXHAUSTED"}
+
+var transientErrorCodes = []string{"TEMPORARILY_UNAVAILABLE", "INTERNAL_ERROR", "INTERNAL_SERVER_ERROR", "SERVICE_UNAVAILABLE", "DEADLINE_EXCEEDED", "ABORTED", "IO_ERROR"}
+
+// classifyRetryError determines whether an error is worth retrying, and why.
+// Any of the extra retryErrors, matched against the API error code or HTTP
+// status code, is treated as throttling.
+func classifyRetryError(err error, retryErrors []string) retryClass {
+	var apiErr *apierr.APIError
+	if errors.As(err, &apiErr) {
+		for _, msg := range retryErrors {
+			if strings.Contains(apiErr.ErrorCode, msg) || strings.Contains(strconv.Itoa(apiErr.StatusCode), msg) {
+				return retryClassThrottled
 			}
 		}
-		return false
+		if apiErr.StatusCode == http.StatusTooManyRequests || slices.Contains(throttledErrorCodes, apiErr.ErrorCode) {
+			return retryClassThrottled
+		}
+		if slices.Contains(transientErrorCodes, apiErr.ErrorCode) {
+			return retryClassTransient
+		}
+		// 501 Not Implemented will not succeed on a retry
+		if apiErr.StatusCode >= 500 && apiErr.StatusCode != http.StatusNotImplemented {
+			return retryClassTransient
+		}
+		if apiErr.IsRetriable() {
+			return retryClassTransient
+		}
+		return retryClassNone
+	}
+
+	// Network errors, e.g. connection resets, refused connections and timeouts
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return retryClassTransient
+	}
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return retryClassTransient
 	}
+	if strings.Contains(err.Error(), "connection reset by peer") {
+		return retryClassTransient
+	}
+
+	return retryClassNone
 }
 
-func buildQueryFilterFromQuals(filterQuals []filterQualMap, equalQuals plugin.KeyColumnQualMap) string {
+// retryErrorCodes are the error codes or HTTP status codes retried in
+// addition to those classified by classifyRetryError. There are none by
+// default, and throttled 429 responses are never among them, since the SDK
+// client retries those itself.
+var retryErrorCodes []string
 
-	filters := ""
+// hydrateRetryConfig returns the retry config for a hydrate function. The
+// plugin SDK does not pass the hydrate function to the retry predicate, so
+// the predicate is bound to its name, which keeps the retries of different
+// hydrate functions on the same item from sharing one budget.
+func hydrateRetryConfig(hydrate plugin.HydrateFunc) *plugin.RetryConfig {
+	return &plugin.RetryConfig{
+		ShouldRetryErrorFunc: shouldRetryError(hydrateName(hydrate), retryErrorCodes),
+		MaxAttempts:          maxRetryAttemptsLimit,
+		// The backoff delay is applied by shouldRetryError
+		BackoffAlgorithm: "Constant",
+		RetryInterval:    1,
+	}
+}
 
-	for _, filterQualItem := range filterQuals {
-		filterQual := equalQuals[filterQualItem.ColumnName]
-		if filterQual == nil {
+// setHydrateRetryConfigs sets the retry config of the list, get and column
+// hydrate functions of a table which do not declare their own.
+func setHydrateRetryConfigs(table *plugin.Table) {
+	if table.List != nil && table.List.RetryConfig == nil {
+		table.List.RetryConfig = hydrateRetryConfig(table.List.Hydrate)
+	}
+	if table.Get != nil && table.Get.RetryConfig == nil {
+		table.Get.RetryConfig = hydrateRetryConfig(table.Get.Hydrate)
+	}
+
+	configured := map[string]bool{}
+	if table.Get != nil {
+		configured[hydrateName(table.Get.Hydrate)] = true
+	}
+	for i := range table.HydrateConfig {
+		config := &table.HydrateConfig[i]
+		if config.RetryConfig == nil {
+			config.RetryConfig = hydrateRetryConfig(config.Func)
+		}
+		configured[hydrateName(config.Func)] = true
+	}
+	for _, column := range table.Columns {
+		if column.Hydrate == nil || configured[hydrateName(column.Hydrate)] {
 			continue
 		}
+		table.HydrateConfig = append(table.HydrateConfig, plugin.HydrateConfig{
+			Func:        column.Hydrate,
+			RetryConfig: hydrateRetryConfig(column.Hydrate),
+		})
+		configured[hydrateName(column.Hydrate)] = true
+	}
+}
 
-		// Check only if filter qual map matches with optional column name
-		if filterQual.Name == filterQualItem.ColumnName {
-			if filterQual.Quals == nil {
-				continue
-			}
+func hydrateName(hydrate plugin.HydrateFunc) string {
+	name := runtime.FuncForPC(reflect.ValueOf(hydrate).Pointer()).Name()
+	return name[strings.LastIndex(name, ".")+1:]
+}
 
-			for _, qual := range filterQual.Quals {
-				if qual.Value != nil {
-					value := qual.Value
-					switch filterQualItem.Type {
-					case "string":
-						switch qual.Operator {
-						case "=", "<>":
-							if filters != "" {
-								filters += " and "
-							}
-							filters += filterQualItem.PropertyPath + DatabricksCompositeFilterOperatorMap[qual.Operator] + value.GetStringValue()
-						}
-					}
-				}
+// shouldRetryError returns a retry predicate for the calls to a hydrate
+// function. Throttled requests are retried up to max_retries times and
+// transient errors up to max_error_retry_attempts times, either of which is
+// capped at maxRetryAttemptsLimit. Errors the SDK client has already retried
+// are returned as is.
+func shouldRetryError(hydrateName string, retryErrors []string) plugin.ErrorPredicateWithContext {
+	return func(ctx context.Context, d *plugin.QueryData, h *plugin.HydrateData, err error) bool {
+		if retriedByClient(err) {
+			return false
+		}
+		class := classifyRetryError(err, retryErrors)
+		if class == retryClassNone {
+			return false
+		}
+
+		config := GetConfig(d.Connection)
+		maxAttempts := defaultMaxErrorRetryAttempts
+		if class == retryClassThrottled {
+			maxAttempts = defaultMaxRetries
+			if config.MaxRetries != nil {
+				maxAttempts = *config.MaxRetries
 			}
+		} else if config.MaxErrorRetryAttempts != nil {
+			maxAttempts = *config.MaxErrorRetryAttempts
+		}
+
+		key := retryAttemptKey(d, h, hydrateName)
+		attempt := retryAttempts.next(ctx, key)
+		if attempt > maxAttempts {
+			plugin.Logger(ctx).Error("databricks_errors.shouldRetryError", "hydrate", hydrateName, "retries_exhausted", err, "attempts", attempt-1)
+			retryAttempts.reset(key)
+			return false
 		}
+
+		minDelay := defaultMinRetryDelay
+		if config.MinRetryDelay != nil {
+			minDelay = time.Duration(*config.MinRetryDelay) * time.Millisecond
+		}
+		delay := retryDelay(minDelay, attempt)
+
+		if class == retryClassThrottled {
+			plugin.Logger(ctx).Warn("databricks_errors.shouldRetryError", "hydrate", hydrateName, "rate_limit_error", err, "attempt", attempt, "delay", delay)
+		} else {
+			plugin.Logger(ctx).Warn("databricks_errors.shouldRetryError", "hydrate", hydrateName, "transient_error", err, "attempt", attempt, "delay", delay)
+		}
+
+		// Wait here rather than in the plugin backoff, so that the delay can
+		// be configured per connection
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(delay):
+		}
+
+		return true
+	}
+}
+
+// retryDelay returns an exponential backoff with jitter, starting from the
+// minimum delay and capped at maxRetryDelay.
+func retryDelay(minDelay time.Duration, attempt int) time.Duration {
+	delay := minDelay
+	for i := 1; i < attempt && delay < maxRetryDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	// Up to 25% jitter avoids retrying fan-out hydrate calls in lock step
+	if delay > 0 {
+		delay += time.Duration(rand.Int64N(int64(delay)/4 + 1))
+	}
+	return delay
+}
+
+// retriedByClient reports whether the SDK client has already retried a failed
+// request until its retry timeout, which it does for throttled 429 responses
+// and the errors it considers transient. Retrying these again in the plugin
+// would multiply the time a query stalls.
+func retriedByClient(err error) bool {
+	var apiErr *apierr.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.IsRetriable()
+}
+
+// clientRetryTimeoutSeconds returns the retry timeout of the SDK client which
+// allows about maxRetries retries of a throttled request. The SDK waits one
+// second longer before each retry, up to 10 seconds, plus up to 750ms of
+// jitter, and does not honour the Retry-After header of the response.
+func clientRetryTimeoutSeconds(maxRetries int) int {
+	timeout := 0
+	for attempt := 1; attempt <= maxRetries; attempt++ {
+		timeout += min(attempt, 10) + 1
 	}
+	// A zero timeout would fall back to the SDK default of 5 minutes
+	return max(timeout, 1)
+}
 
-	return filters
+// retryAttemptKey identifies a single hydrate call across its retries. The
+// plugin SDK passes a fresh HydrateData to the retries, so the key is built
+// from the hydrate function and the item being hydrated rather than the
+// HydrateData itself.
+func retryAttemptKey(d *plugin.QueryData, h *plugin.HydrateData, hydrateName string) string {
+	if h == nil {
+		return fmt.Sprintf("%p-%s", d, hydrateName)
+	}
+	return fmt.Sprintf("%p-%s-%v-%v", d, hydrateName, h.Item, h.ParentItem)
 }
 
-type filterQualMap struct {
-	ColumnName   string
-	PropertyPath string
-	Type         string
+// retryAttemptCounter counts the retries of each hydrate call. A call which
+// succeeds is never retried again under the same key, and its entry is
+// released once the query finishes.
+type retryAttemptCounter struct {
+	mu       sync.Mutex
+	attempts map[string]int
+}
+
+var retryAttempts = &retryAttemptCounter{attempts: map[string]int{}}
+
+func (c *retryAttemptCounter) next(ctx context.Context, key string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.attempts[key]; !ok {
+		context.AfterFunc(ctx, func() { c.reset(key) })
+	}
+	c.attempts[key]++
+	return c.attempts[key]
 }
 
-var DatabricksCompositeFilterOperatorMap = map[string]string{
-	"=":  " eq ",
-	"<>": " ne ",
-	"!=": " ne ",
+func (c *retryAttemptCounter) reset(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.attempts, key)
+}
+
+// minTime returns the tighter of two upper bounds of a time range in
+// milliseconds, where 0 is unbounded.
+func minTime[T int | int64](current, ms T) T {
+	if current == 0 || ms < current {
+		return ms
+	}
+	return current
+}
+
+// qualValues returns the values of the equality quals on a column, expanding
+// IN lists. The plugin SDK only splits a query into one list call per value
+// when a single column has an IN list, otherwise the lists are passed through.
+func qualValues(columnQuals *plugin.KeyColumnQuals) []*proto.QualValue {
+	if columnQuals == nil {
+		return nil
+	}
+	var values []*proto.QualValue
+	for _, q := range columnQuals.Quals {
+		if q.Operator != quals.QualOperatorEqual || q.Value == nil {
+			continue
+		}
+		if list := q.Value.GetListValue(); list != nil {
+			values = append(values, list.Values...)
+		} else {
+			values = append(values, q.Value)
+		}
+	}
+	return values
 }
@@ -0,0 +1,154 @@
+package databricks
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/databricks/databricks-sdk-go/apierr"
+	"github.com/turbot/steampipe-plugin-sdk/v5/plugin"
+)
+
+func TestClassifyRetryError(t *testing.T) {
+	for _, test := range []struct {
+		name        string
+		err         error
+		retryErrors []string
+		want        retryClass
+	}{
+		{"too many requests", &apierr.APIError{ErrorCode: "TOO_MANY_REQUESTS", StatusCode: 429}, nil, retryClassThrottled},
+		{"request limit exceeded", &apierr.APIError{ErrorCode: "REQUEST_LIMIT_EXCEEDED", StatusCode: 400}, nil, retryClassThrottled},
+		{"extra error code", &apierr.APIError{ErrorCode: "QUOTA_EXCEEDED", StatusCode: 400}, []string{"QUOTA_EXCEEDED"}, retryClassThrottled},
+		{"extra status code", &apierr.APIError{ErrorCode: "BAD_REQUEST", StatusCode: 409}, []string{"409"}, retryClassThrottled},
+		{"temporarily unavailable", &apierr.APIError{ErrorCode: "TEMPORARILY_UNAVAILABLE", StatusCode: 400}, nil, retryClassTransient},
+		{"server error", &apierr.APIError{ErrorCode: "UNKNOWN", StatusCode: 502}, nil, retryClassTransient},
+		{"not implemented", &apierr.APIError{ErrorCode: "NOT_IMPLEMENTED", StatusCode: 501}, nil, retryClassNone},
+		{"transient message", &apierr.APIError{ErrorCode: "INVALID_STATE", StatusCode: 400, Message: "ClusterNotReadyException"}, nil, retryClassTransient},
+		{"not found", &apierr.APIError{ErrorCode: "RESOURCE_DOES_NOT_EXIST", StatusCode: 404}, nil, retryClassNone},
+		{"permission denied", &apierr.APIError{ErrorCode: "PERMISSION_DENIED", StatusCode: 403}, nil, retryClassNone},
+		{"wrapped api error", fmt.Errorf("listing jobs: %w", &apierr.APIError{ErrorCode: "TOO_MANY_REQUESTS", StatusCode: 429}), nil, retryClassThrottled},
+		{"timeout", &net.OpError{Op: "read", Err: timeoutError{}}, nil, retryClassTransient},
+		{"connection reset", fmt.Errorf("failed request: %w", syscall.ECONNRESET), nil, retryClassTransient},
+		{"unexpected eof", io.ErrUnexpectedEOF, nil, retryClassTransient},
+		{"other error", errors.New("invalid character in JSON"), nil, retryClassNone},
+	} {
+		if got := classifyRetryError(test.err, test.retryErrors); got != test.want {
+			t.Errorf("%s: classifyRetryError = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestThrottledRetries(t *testing.T) {
+	// The SDK client retries a 429 response itself, without waiting for the
+	// minute the Retry-After header asks for
+	fake := newFakeDatabricks(t)
+	fake.throttled["/api/2.1/jobs/list"] = 2
+	p := newTestPlugin(t, fake, "max_retries = 2")
+
+	start := time.Now()
+	rows := p.query(t, "databricks_job", []string{"job_id"}, nil, 0)
+
+	if len(rows) == 0 {
+		t.Errorf("got no jobs, want the jobs listed after two retries")
+	}
+	// The first page is throttled twice before it is listed
+	if got, want := len(fake.requestsTo("/api/2.1/jobs/list")), 4; got != want {
+		t.Errorf("got %d requests to list jobs, want %d", got, want)
+	}
+	if elapsed := time.Since(start); elapsed > time.Duration(clientRetryTimeoutSeconds(2))*time.Second {
+		t.Errorf("listing jobs took %v, want it bounded by the client retry timeout", elapsed)
+	}
+
+	// Once the client retry timeout is spent, the plugin does not retry the
+	// request again
+	fake = newFakeDatabricks(t)
+	fake.throttled["/api/2.1/jobs/list"] = 5
+	p = newTestPlugin(t, fake, "max_retries = 1", "min_retry_delay = 1")
+
+	if _, err := p.tryQuery("databricks_job", []string{"job_id"}, nil, 0); err == nil {
+		t.Errorf("expected an error once the retries are exhausted")
+	}
+	if got, want := len(fake.requestsTo("/api/2.1/jobs/list")), 2; got != want {
+		t.Errorf("got %d requests to list jobs, want %d", got, want)
+	}
+}
+
+func TestClientRetryTimeoutSeconds(t *testing.T) {
+	for _, test := range []struct {
+		maxRetries, want int
+	}{
+		{0, 1},
+		{1, 2},
+		{2, 5},
+		{10, 65},
+		{12, 87},
+	} {
+		if got := clientRetryTimeoutSeconds(test.maxRetries); got != test.want {
+			t.Errorf("clientRetryTimeoutSeconds(%d) = %d, want %d", test.maxRetries, got, test.want)
+		}
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	fake := newFakeDatabricks(t)
+	fake.unavailable["/api/2.1/jobs/list"] = 2
+	p := newTestPlugin(t, fake, "max_error_retry_attempts = 2", "min_retry_delay = 1")
+
+	rows := p.query(t, "databricks_job", []string{"job_id"}, nil, 0)
+
+	if len(rows) == 0 {
+		t.Errorf("got no jobs, want the jobs listed after two retries")
+	}
+	// The first page fails twice before it is listed
+	if got, want := len(fake.requestsTo("/api/2.1/jobs/list")), 4; got != want {
+		t.Errorf("got %d requests to list jobs, want %d", got, want)
+	}
+
+	fake = newFakeDatabricks(t)
+	fake.unavailable["/api/2.1/jobs/list"] = 3
+	p = newTestPlugin(t, fake, "max_error_retry_attempts = 2", "min_retry_delay = 1")
+
+	if _, err := p.tryQuery("databricks_job", []string{"job_id"}, nil, 0); err == nil {
+		t.Errorf("expected an error once the retries are exhausted")
+	}
+	if got, want := len(fake.requestsTo("/api/2.1/jobs/list")), 3; got != want {
+		t.Errorf("got %d requests to list jobs, want %d", got, want)
+	}
+}
+
+func TestRetryAttemptKey(t *testing.T) {
+	h := &plugin.HydrateData{Item: map[string]interface{}{"job_id": 1}}
+
+	// The retries of different hydrate functions on the same item are
+	// counted separately
+	if retryAttemptKey(nil, h, "getJob") == retryAttemptKey(nil, h, "getJobPermissions") {
+		t.Errorf("retry attempt keys of different hydrate functions are equal")
+	}
+	if got, want := retryAttemptKey(nil, h, "getJob"), retryAttemptKey(nil, &plugin.HydrateData{Item: h.Item}, "getJob"); got != want {
+		t.Errorf("retry attempt key = %q, want %q for a retry of the same call", got, want)
+	}
+}
+
+func TestMinTime(t *testing.T) {
+	for _, test := range []struct {
+		current, ms, want int64
+	}{
+		{0, 1700000000000, 1700000000000},
+		{1700000000000, 1600000000000, 1600000000000},
+		{1600000000000, 1700000000000, 1600000000000},
+	} {
+		if got := minTime(test.current, test.ms); got != test.want {
+			t.Errorf("minTime(%d, %d) = %d, want %d", test.current, test.ms, got, test.want)
+		}
+	}
+}
@@ -5,6 +5,7 @@ go 1.26.0
 require (
 	github.com/databricks/databricks-sdk-go v0.12.0
 	github.com/turbot/steampipe-plugin-sdk/v5 v5.14.0
+	google.golang.org/protobuf v1.34.2
 )
 
 require (
@@ -105,7 +106,6 @@ require (
 	google.golang.org/genproto/googleapis/api v0.0.0-20240604185151-ef581f913117 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20240604185151-ef581f913117 // indirect
 	google.golang.org/grpc v1.66.0 // indirect
-	google.golang.org/protobuf v1.34.2 // indirect
 	gopkg.in/ini.v1 v1.67.0 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 )